@@ -1,11 +1,11 @@
 package v1
 
 import (
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 // +genclient
-// +genclient:noStatus
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 type HostGroup struct {
 	metav1.TypeMeta   `json:",inline"`
@@ -21,6 +21,7 @@ type HostGroupSpec struct {
 }
 
 type HostGroupStatus struct {
+	SyncStatus `json:",inline"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -32,7 +33,6 @@ type HostGroupList struct {
 }
 
 // +genclient
-// +genclient:noStatus
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 type Host struct {
 	metav1.TypeMeta   `json:",inline"`
@@ -52,6 +52,9 @@ type HostSpec struct {
 }
 
 type HostStatus struct {
+	SyncStatus `json:",inline"`
+
+	CheckResult *CheckResult `json:"checkresult,omitempty"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -63,7 +66,6 @@ type HostList struct {
 }
 
 // +genclient
-// +genclient:noStatus
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 type Check struct {
 	metav1.TypeMeta   `json:",inline"`
@@ -83,6 +85,9 @@ type CheckSpec struct {
 }
 
 type CheckStatus struct {
+	SyncStatus `json:",inline"`
+
+	CheckResult *CheckResult `json:"checkresult,omitempty"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -92,3 +97,42 @@ type CheckList struct {
 
 	Items []Check `json:"items"`
 }
+
+// The type of a condition in the status of a HostGroup, Host or Check.
+type ConditionType string
+
+const (
+	// The object was written to Icinga and is up to date.
+	ConditionSynced ConditionType = "Synced"
+
+	// Icinga refused to create or update the object.
+	ConditionIcingaRejected ConditionType = "IcingaRejected"
+
+	// An Icinga object with the same name exists, but it was not created by us.
+	ConditionNotManagedByUs ConditionType = "NotManagedByUs"
+)
+
+type Condition struct {
+	Type               ConditionType          `json:"type"`
+	Status             corev1.ConditionStatus `json:"status"`
+	Reason             string                 `json:"reason,omitempty"`
+	Message            string                 `json:"message,omitempty"`
+	LastTransitionTime metav1.Time            `json:"lasttransitiontime,omitempty"`
+}
+
+// The result of the last attempt to sync an object to Icinga.
+type SyncStatus struct {
+	Conditions         []Condition  `json:"conditions,omitempty"`
+	IcingaName         string       `json:"icinganame,omitempty"`
+	ObservedGeneration int64        `json:"observedgeneration,omitempty"`
+	LastSyncTime       *metav1.Time `json:"lastsynctime,omitempty"`
+	LastError          string       `json:"lasterror,omitempty"`
+}
+
+// The current state of an Icinga host or service, as read from the Icinga API.
+type CheckResult struct {
+	State           string       `json:"state"`
+	StateType       string       `json:"statetype"`
+	Output          string       `json:"output,omitempty"`
+	LastStateChange *metav1.Time `json:"laststatechange,omitempty"`
+}
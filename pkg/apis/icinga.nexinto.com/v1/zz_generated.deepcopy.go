@@ -30,7 +30,7 @@ func (in *Check) DeepCopyInto(out *Check) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 	return
 }
 
@@ -85,6 +85,26 @@ func (in *CheckList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CheckResult) DeepCopyInto(out *CheckResult) {
+	*out = *in
+	if in.LastStateChange != nil {
+		in, out := &in.LastStateChange, &out.LastStateChange
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CheckResult.
+func (in *CheckResult) DeepCopy() *CheckResult {
+	if in == nil {
+		return nil
+	}
+	out := new(CheckResult)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CheckSpec) DeepCopyInto(out *CheckSpec) {
 	*out = *in
@@ -111,6 +131,12 @@ func (in *CheckSpec) DeepCopy() *CheckSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CheckStatus) DeepCopyInto(out *CheckStatus) {
 	*out = *in
+	in.SyncStatus.DeepCopyInto(&out.SyncStatus)
+	if in.CheckResult != nil {
+		in, out := &in.CheckResult, &out.CheckResult
+		*out = new(CheckResult)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -124,13 +150,30 @@ func (in *CheckStatus) DeepCopy() *CheckStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Condition) DeepCopyInto(out *Condition) {
+	*out = *in
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Condition.
+func (in *Condition) DeepCopy() *Condition {
+	if in == nil {
+		return nil
+	}
+	out := new(Condition)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Host) DeepCopyInto(out *Host) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 	return
 }
 
@@ -158,7 +201,7 @@ func (in *HostGroup) DeepCopyInto(out *HostGroup) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 	return
 }
 
@@ -239,6 +282,7 @@ func (in *HostGroupSpec) DeepCopy() *HostGroupSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HostGroupStatus) DeepCopyInto(out *HostGroupStatus) {
 	*out = *in
+	in.SyncStatus.DeepCopyInto(&out.SyncStatus)
 	return
 }
 
@@ -316,6 +360,12 @@ func (in *HostSpec) DeepCopy() *HostSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HostStatus) DeepCopyInto(out *HostStatus) {
 	*out = *in
+	in.SyncStatus.DeepCopyInto(&out.SyncStatus)
+	if in.CheckResult != nil {
+		in, out := &in.CheckResult, &out.CheckResult
+		*out = new(CheckResult)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -328,3 +378,30 @@ func (in *HostStatus) DeepCopy() *HostStatus {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SyncStatus) DeepCopyInto(out *SyncStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.LastSyncTime != nil {
+		in, out := &in.LastSyncTime, &out.LastSyncTime
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SyncStatus.
+func (in *SyncStatus) DeepCopy() *SyncStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SyncStatus)
+	in.DeepCopyInto(out)
+	return out
+}
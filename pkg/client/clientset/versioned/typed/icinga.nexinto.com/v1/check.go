@@ -37,6 +37,7 @@ type ChecksGetter interface {
 type CheckInterface interface {
 	Create(*v1.Check) (*v1.Check, error)
 	Update(*v1.Check) (*v1.Check, error)
+	UpdateStatus(*v1.Check) (*v1.Check, error)
 	Delete(name string, options *meta_v1.DeleteOptions) error
 	DeleteCollection(options *meta_v1.DeleteOptions, listOptions meta_v1.ListOptions) error
 	Get(name string, options meta_v1.GetOptions) (*v1.Check, error)
@@ -120,6 +121,22 @@ func (c *checks) Update(check *v1.Check) (result *v1.Check, err error) {
 	return
 }
 
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+
+func (c *checks) UpdateStatus(check *v1.Check) (result *v1.Check, err error) {
+	result = &v1.Check{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("checks").
+		Name(check.Name).
+		SubResource("status").
+		Body(check).
+		Do().
+		Into(result)
+	return
+}
+
 // Delete takes name of the check and deletes it. Returns an error if one occurs.
 func (c *checks) Delete(name string, options *meta_v1.DeleteOptions) error {
 	return c.client.Delete().
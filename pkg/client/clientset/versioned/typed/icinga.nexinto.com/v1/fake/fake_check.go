@@ -100,6 +100,18 @@ func (c *FakeChecks) Update(check *icinga_nexinto_com_v1.Check) (result *icinga_
 	return obj.(*icinga_nexinto_com_v1.Check), err
 }
 
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *FakeChecks) UpdateStatus(check *icinga_nexinto_com_v1.Check) (*icinga_nexinto_com_v1.Check, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateSubresourceAction(checksResource, "status", c.ns, check), &icinga_nexinto_com_v1.Check{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*icinga_nexinto_com_v1.Check), err
+}
+
 // Delete takes name of the check and deletes it. Returns an error if one occurs.
 func (c *FakeChecks) Delete(name string, options *v1.DeleteOptions) error {
 	_, err := c.Fake.
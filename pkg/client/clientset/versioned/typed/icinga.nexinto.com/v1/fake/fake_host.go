@@ -100,6 +100,18 @@ func (c *FakeHosts) Update(host *icinga_nexinto_com_v1.Host) (result *icinga_nex
 	return obj.(*icinga_nexinto_com_v1.Host), err
 }
 
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *FakeHosts) UpdateStatus(host *icinga_nexinto_com_v1.Host) (*icinga_nexinto_com_v1.Host, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateSubresourceAction(hostsResource, "status", c.ns, host), &icinga_nexinto_com_v1.Host{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*icinga_nexinto_com_v1.Host), err
+}
+
 // Delete takes name of the host and deletes it. Returns an error if one occurs.
 func (c *FakeHosts) Delete(name string, options *v1.DeleteOptions) error {
 	_, err := c.Fake.
@@ -100,6 +100,18 @@ func (c *FakeHostGroups) Update(hostGroup *icinga_nexinto_com_v1.HostGroup) (res
 	return obj.(*icinga_nexinto_com_v1.HostGroup), err
 }
 
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *FakeHostGroups) UpdateStatus(hostGroup *icinga_nexinto_com_v1.HostGroup) (*icinga_nexinto_com_v1.HostGroup, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateSubresourceAction(hostgroupsResource, "status", c.ns, hostGroup), &icinga_nexinto_com_v1.HostGroup{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*icinga_nexinto_com_v1.HostGroup), err
+}
+
 // Delete takes name of the hostGroup and deletes it. Returns an error if one occurs.
 func (c *FakeHostGroups) Delete(name string, options *v1.DeleteOptions) error {
 	_, err := c.Fake.
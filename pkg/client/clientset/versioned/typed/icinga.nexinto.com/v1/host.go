@@ -37,6 +37,7 @@ type HostsGetter interface {
 type HostInterface interface {
 	Create(*v1.Host) (*v1.Host, error)
 	Update(*v1.Host) (*v1.Host, error)
+	UpdateStatus(*v1.Host) (*v1.Host, error)
 	Delete(name string, options *meta_v1.DeleteOptions) error
 	DeleteCollection(options *meta_v1.DeleteOptions, listOptions meta_v1.ListOptions) error
 	Get(name string, options meta_v1.GetOptions) (*v1.Host, error)
@@ -120,6 +121,22 @@ func (c *hosts) Update(host *v1.Host) (result *v1.Host, err error) {
 	return
 }
 
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+
+func (c *hosts) UpdateStatus(host *v1.Host) (result *v1.Host, err error) {
+	result = &v1.Host{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("hosts").
+		Name(host.Name).
+		SubResource("status").
+		Body(host).
+		Do().
+		Into(result)
+	return
+}
+
 // Delete takes name of the host and deletes it. Returns an error if one occurs.
 func (c *hosts) Delete(name string, options *meta_v1.DeleteOptions) error {
 	return c.client.Delete().
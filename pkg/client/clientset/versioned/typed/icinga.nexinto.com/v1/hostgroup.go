@@ -37,6 +37,7 @@ type HostGroupsGetter interface {
 type HostGroupInterface interface {
 	Create(*v1.HostGroup) (*v1.HostGroup, error)
 	Update(*v1.HostGroup) (*v1.HostGroup, error)
+	UpdateStatus(*v1.HostGroup) (*v1.HostGroup, error)
 	Delete(name string, options *meta_v1.DeleteOptions) error
 	DeleteCollection(options *meta_v1.DeleteOptions, listOptions meta_v1.ListOptions) error
 	Get(name string, options meta_v1.GetOptions) (*v1.HostGroup, error)
@@ -120,6 +121,22 @@ func (c *hostGroups) Update(hostGroup *v1.HostGroup) (result *v1.HostGroup, err
 	return
 }
 
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+
+func (c *hostGroups) UpdateStatus(hostGroup *v1.HostGroup) (result *v1.HostGroup, err error) {
+	result = &v1.HostGroup{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("hostgroups").
+		Name(hostGroup.Name).
+		SubResource("status").
+		Body(hostGroup).
+		Do().
+		Into(result)
+	return
+}
+
 // Delete takes name of the hostGroup and deletes it. Returns an error if one occurs.
 func (c *hostGroups) Delete(name string, options *meta_v1.DeleteOptions) error {
 	return c.client.Delete().
@@ -0,0 +1,86 @@
+package main
+
+import (
+	log "github.com/sirupsen/logrus"
+
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+func (c *Controller) ValidatingWebhookConfigurationCreatedOrUpdated(wc *admissionregistrationv1.ValidatingWebhookConfiguration) error {
+	return c.processInfrastructure(wc, "vwc", "validatingwebhookconfiguration", "ValidatingWebhookConfiguration", "admissionregistration.k8s.io/v1")
+}
+
+func (c *Controller) ValidatingWebhookConfigurationDeleted(wc *admissionregistrationv1.ValidatingWebhookConfiguration) error {
+	log.Debugf("processing deleted validatingwebhookconfiguration '%s'", wc.Name)
+	return c.Mapping.UnmonitorInfrastructure(c, wc, "vwc")
+}
+
+func (c *Controller) MutatingWebhookConfigurationCreatedOrUpdated(wc *admissionregistrationv1.MutatingWebhookConfiguration) error {
+	return c.processInfrastructure(wc, "mwc", "mutatingwebhookconfiguration", "MutatingWebhookConfiguration", "admissionregistration.k8s.io/v1")
+}
+
+func (c *Controller) MutatingWebhookConfigurationDeleted(wc *admissionregistrationv1.MutatingWebhookConfiguration) error {
+	log.Debugf("processing deleted mutatingwebhookconfiguration '%s'", wc.Name)
+	return c.Mapping.UnmonitorInfrastructure(c, wc, "mwc")
+}
+
+// Cluster scoped objects are monitored as infrastructure.
+func (c *Controller) processInfrastructure(o metav1.Object, abbrev, typ, kind, apiVersion string) error {
+	log.Debugf("processing %s '%s'", typ, o.GetName())
+	if !c.monitored(o) || !c.checkable(typ) || o.GetDeletionTimestamp() != nil {
+		return c.Mapping.UnmonitorInfrastructure(c, o, abbrev)
+	}
+
+	if err := c.Mapping.MonitorInfrastructure(c, o, abbrev, typ, kind, apiVersion); err != nil {
+		return err
+	}
+	checkableType, name := c.Mapping.InfrastructureCheckable(c, o, abbrev)
+	c.submitCheckResult(checkableType, name, o)
+	return nil
+}
+
+// The Endpoints of the Services webhooks point to, by namespace/name. Services without Endpoints are missing.
+func (c *Controller) webhookEndpoints(configs ...admissionregistrationv1.WebhookClientConfig) map[string]*corev1.Endpoints {
+	eps := make(map[string]*corev1.Endpoints)
+	for _, config := range configs {
+		if config.Service == nil {
+			continue
+		}
+		if ep, err := c.EndpointsLister.Endpoints(config.Service.Namespace).Get(config.Service.Name); err == nil {
+			eps[config.Service.Namespace+"/"+config.Service.Name] = ep
+		}
+	}
+	return eps
+}
+
+// Webhook configurations change their state with the Endpoints of their Services.
+func (c *Controller) requeueWebhookConfigurations(endpoints *corev1.Endpoints) {
+	points := func(config admissionregistrationv1.WebhookClientConfig) bool {
+		return config.Service != nil && config.Service.Namespace == endpoints.Namespace && config.Service.Name == endpoints.Name
+	}
+
+	if configs, err := c.ValidatingWebhookConfigurationLister.List(labels.Everything()); err == nil {
+		for _, wc := range configs {
+			for _, w := range wc.Webhooks {
+				if points(w.ClientConfig) {
+					c.ValidatingWebhookConfigurationQueue.Add(wc.Name)
+					break
+				}
+			}
+		}
+	}
+
+	if configs, err := c.MutatingWebhookConfigurationLister.List(labels.Everything()); err == nil {
+		for _, wc := range configs {
+			for _, w := range wc.Webhooks {
+				if points(w.ClientConfig) {
+					c.MutatingWebhookConfigurationQueue.Add(wc.Name)
+					break
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic/dynamicinformer"
+	"k8s.io/client-go/util/workqueue"
+)
+
+// APIServices are served by the aggregator and not part of the Kubernetes clientset, so they are watched with a
+// dynamic informer.
+var apiServiceResource = schema.GroupVersionResource{Group: "apiregistration.k8s.io", Version: "v1", Resource: "apiservices"}
+
+// Set up an informer for APIServices. Expects c.Dynamic to be set.
+func (c *Controller) InitializeAPIServices() {
+	if c.Dynamic == nil {
+		panic("c.Dynamic is nil")
+	}
+
+	c.APIServiceFactory = dynamicinformer.NewDynamicSharedInformerFactory(c.Dynamic, time.Second*60)
+
+	informer := c.APIServiceFactory.ForResource(apiServiceResource)
+	c.APIServiceQueue = workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
+	c.APIServiceLister = informer.Lister()
+	c.APIServiceSynced = informer.Informer().HasSynced
+
+	enqueue(informer.Informer(), c.APIServiceQueue, func(obj interface{}) error {
+		o, ok := obj.(*unstructured.Unstructured)
+		if !ok {
+			return fmt.Errorf("object is not an APIService %+v", obj)
+		}
+		return c.APIServiceDeleted(o)
+	})
+}
+
+func (c *Controller) RunAPIServices(stopCh <-chan struct{}) {
+	runQueue(c.APIServiceFactory, c.APIServiceSynced, c.APIServiceQueue, c.processAPIService, stopCh)
+}
+
+func (c *Controller) processAPIService(key string) error {
+	o, err := c.APIServiceLister.Get(key)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return fmt.Errorf("tried to get %s, but it was not found", key)
+		} else {
+			return fmt.Errorf("error getting %s from cache: %s", key, err.Error())
+		}
+	}
+
+	u, ok := o.(*unstructured.Unstructured)
+	if !ok {
+		return fmt.Errorf("%s is not unstructured", key)
+	}
+
+	return c.APIServiceCreatedOrUpdated(u)
+}
+
+// APIServices are critical if they are not Available, for example because the aggregated API server is down.
+func (c *Controller) APIServiceCreatedOrUpdated(o *unstructured.Unstructured) error {
+	return c.processInfrastructure(o, "apiservice", "apiservice", "APIService", "apiregistration.k8s.io/v1")
+}
+
+func (c *Controller) APIServiceDeleted(o *unstructured.Unstructured) error {
+	log.Debugf("processing deleted apiservice '%s'", o.GetName())
+	return c.Mapping.UnmonitorInfrastructure(c, o, "apiservice")
+}
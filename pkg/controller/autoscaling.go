@@ -0,0 +1,125 @@
+package main
+
+import (
+	"fmt"
+
+	log "github.com/sirupsen/logrus"
+
+	autoscalingv2beta2 "k8s.io/api/autoscaling/v2beta2"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+
+	icingav1 "github.com/Nexinto/kubernetes-icinga/pkg/apis/icinga.nexinto.com/v1"
+)
+
+// Set up an informer for HorizontalPodAutoscalers, if the cluster serves autoscaling/v2beta2. Kubernetes 1.26
+// removed it.
+func (c *Controller) InitializeHorizontalPodAutoscalers() {
+	if !servesResource(c.Kubernetes.Discovery(), "autoscaling/v2beta2", "horizontalpodautoscalers") {
+		return
+	}
+
+	informer := c.KubernetesFactory.Autoscaling().V2beta2().HorizontalPodAutoscalers()
+	c.HorizontalPodAutoscalerQueue = workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
+	c.HorizontalPodAutoscalerLister = informer.Lister()
+	c.HorizontalPodAutoscalerSynced = informer.Informer().HasSynced
+
+	enqueue(informer.Informer(), c.HorizontalPodAutoscalerQueue, func(obj interface{}) error {
+		o, ok := obj.(*autoscalingv2beta2.HorizontalPodAutoscaler)
+		if !ok {
+			return fmt.Errorf("object is not a HorizontalPodAutoscaler %+v", obj)
+		}
+		return c.HorizontalPodAutoscalerDeleted(o)
+	})
+}
+
+func (c *Controller) RunHorizontalPodAutoscalers(stopCh <-chan struct{}) {
+	if c.HorizontalPodAutoscalerQueue == nil {
+		return
+	}
+	runQueue(c.KubernetesFactory, c.HorizontalPodAutoscalerSynced, c.HorizontalPodAutoscalerQueue, c.processHorizontalPodAutoscaler, stopCh)
+}
+
+func (c *Controller) processHorizontalPodAutoscaler(key string) error {
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		return fmt.Errorf("could not parse name %s: %s", key, err.Error())
+	}
+
+	o, err := c.HorizontalPodAutoscalerLister.HorizontalPodAutoscalers(namespace).Get(name)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return fmt.Errorf("tried to get %s, but it was not found", key)
+		} else {
+			return fmt.Errorf("error getting %s from cache: %s", key, err.Error())
+		}
+	}
+
+	return c.HorizontalPodAutoscalerCreatedOrUpdated(o)
+}
+
+// HorizontalPodAutoscalers are checked next to the workload they scale. Without a monitored target, they are not
+// monitored.
+func (c *Controller) HorizontalPodAutoscalerCreatedOrUpdated(hpa *autoscalingv2beta2.HorizontalPodAutoscaler) error {
+	log.Debugf("processing horizontalpodautoscaler '%s/%s'", hpa.Namespace, hpa.Name)
+
+	target, abbrev := c.scaleTarget(hpa)
+	if target == nil || !c.checkable("horizontalpodautoscaler") || !c.monitored(hpa) || !c.monitored(target) || hpa.GetDeletionTimestamp() != nil {
+		return c.deleteCheck(hpa.Namespace, "hpa-"+hpa.Name)
+	}
+
+	host, name := c.Mapping.AttachedCheck(c, target, abbrev, "hpa-"+hpa.Name)
+
+	err := c.reconcileCheck(&icingav1.Check{
+		ObjectMeta: MakeObjectMeta(hpa, "HorizontalPodAutoscaler", "autoscaling/v2beta2", "hpa", false),
+		Spec: icingav1.CheckSpec{
+			Name:         name,
+			Host:         host,
+			CheckCommand: c.CheckCommand(),
+			Vars:         c.MakeCheckVars(hpa, "horizontalpodautoscaler", true),
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	c.submitCheckResult("Service", c.Tag+"."+host+"!"+name, hpa)
+	return nil
+}
+
+func (c *Controller) HorizontalPodAutoscalerDeleted(hpa *autoscalingv2beta2.HorizontalPodAutoscaler) error {
+	log.Debugf("processing deleted horizontalpodautoscaler '%s/%s'", hpa.Namespace, hpa.Name)
+	return c.deleteCheck(hpa.Namespace, "hpa-"+hpa.Name)
+}
+
+// The workload a HorizontalPodAutoscaler scales and its abbreviation, nil if it does not exist or is of a kind
+// we don't monitor.
+func (c *Controller) scaleTarget(hpa *autoscalingv2beta2.HorizontalPodAutoscaler) (metav1.Object, string) {
+	ref := hpa.Spec.ScaleTargetRef
+
+	var target metav1.Object
+	var abbrev string
+	var err error
+
+	switch ref.Kind {
+	case "Deployment":
+		target, err = c.DeploymentLister.Deployments(hpa.Namespace).Get(ref.Name)
+		abbrev = "deploy"
+	case "StatefulSet":
+		target, err = c.StatefulSetLister.StatefulSets(hpa.Namespace).Get(ref.Name)
+		abbrev = "statefulset"
+	case "ReplicaSet":
+		target, err = c.ReplicaSetLister.ReplicaSets(hpa.Namespace).Get(ref.Name)
+		abbrev = "rs"
+	default:
+		return nil, ""
+	}
+
+	if err != nil {
+		return nil, ""
+	}
+
+	return target, abbrev
+}
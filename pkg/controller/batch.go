@@ -0,0 +1,117 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	batchv1 "k8s.io/api/batch/v1"
+	batchv1beta1 "k8s.io/api/batch/v1beta1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+)
+
+// Set up an informer for CronJobs, if the cluster serves batch/v1beta1. Kubernetes 1.25 removed it.
+func (c *Controller) InitializeCronJobs() {
+	if !servesResource(c.Kubernetes.Discovery(), "batch/v1beta1", "cronjobs") {
+		return
+	}
+
+	informer := c.KubernetesFactory.Batch().V1beta1().CronJobs()
+	c.CronJobQueue = workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
+	c.CronJobLister = informer.Lister()
+	c.CronJobSynced = informer.Informer().HasSynced
+
+	enqueue(informer.Informer(), c.CronJobQueue, func(obj interface{}) error {
+		o, ok := obj.(*batchv1beta1.CronJob)
+		if !ok {
+			return fmt.Errorf("object is not a CronJob %+v", obj)
+		}
+		return c.CronJobDeleted(o)
+	})
+}
+
+func (c *Controller) RunCronJobs(stopCh <-chan struct{}) {
+	if c.CronJobQueue == nil {
+		return
+	}
+	runQueue(c.KubernetesFactory, c.CronJobSynced, c.CronJobQueue, c.processCronJob, stopCh)
+}
+
+func (c *Controller) processCronJob(key string) error {
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		return fmt.Errorf("could not parse name %s: %s", key, err.Error())
+	}
+
+	o, err := c.CronJobLister.CronJobs(namespace).Get(name)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return fmt.Errorf("tried to get %s, but it was not found", key)
+		} else {
+			return fmt.Errorf("error getting %s from cache: %s", key, err.Error())
+		}
+	}
+
+	return c.CronJobCreatedOrUpdated(o)
+}
+
+// Standalone Jobs are monitored until they succeed and JobRetention has passed. Failed Jobs stay monitored until
+// they are deleted. Jobs created by CronJobs are not monitored themselves, but change the state of their CronJob.
+func (c *Controller) JobCreatedOrUpdated(job *batchv1.Job) error {
+	c.requeueCronJob(job)
+
+	if t := job.Status.CompletionTime; t != nil && time.Since(t.Time) > c.JobRetention {
+		log.Debugf("job '%s/%s' completed at %s, no longer monitoring it", job.Namespace, job.Name, t.Time)
+		return c.Mapping.UnmonitorWorkload(c, job, "job")
+	}
+
+	return c.processWorkload(job, "job", "job", "Job", "batch/v1")
+}
+
+func (c *Controller) JobDeleted(job *batchv1.Job) error {
+	log.Debugf("processing deleted job '%s/%s'", job.Namespace, job.Name)
+	c.requeueCronJob(job)
+	return c.Mapping.UnmonitorWorkload(c, job, "job")
+}
+
+func (c *Controller) requeueCronJob(job *batchv1.Job) {
+	if c.CronJobQueue == nil {
+		return
+	}
+	for _, or := range job.OwnerReferences {
+		if or.Kind == "CronJob" {
+			c.CronJobQueue.Add(job.Namespace + "/" + or.Name)
+		}
+	}
+}
+
+func (c *Controller) CronJobCreatedOrUpdated(cronjob *batchv1beta1.CronJob) error {
+	return c.processWorkload(cronjob, "cj", "cronjob", "CronJob", "batch/v1beta1")
+}
+
+func (c *Controller) CronJobDeleted(cronjob *batchv1beta1.CronJob) error {
+	log.Debugf("processing deleted cronjob '%s/%s'", cronjob.Namespace, cronjob.Name)
+	return c.Mapping.UnmonitorWorkload(c, cronjob, "cj")
+}
+
+// The Jobs created by a CronJob.
+func (c *Controller) cronJobJobs(cronjob *batchv1beta1.CronJob) ([]*batchv1.Job, error) {
+	jobs, err := c.JobLister.Jobs(cronjob.Namespace).List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+
+	var owned []*batchv1.Job
+	for _, job := range jobs {
+		if or := metav1.GetControllerOf(job); or != nil && or.UID == cronjob.UID {
+			owned = append(owned, job)
+		}
+	}
+
+	return owned, nil
+}
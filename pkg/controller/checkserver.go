@@ -0,0 +1,197 @@
+package main
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/Nexinto/kubernetes-icinga/pkg/health"
+)
+
+// The result of a check, as returned by the check endpoint.
+type CheckResponse struct {
+	State      string   `json:"state"`
+	ExitStatus int      `json:"exit_status"`
+	Output     string   `json:"output"`
+	PerfData   []string `json:"perfdata"`
+}
+
+// Serve the check endpoint for Icinga.
+func (c *Controller) ServeChecks(addr string) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/check/", c.handleCheck)
+
+	log.Infof("serving checks on %s", addr)
+
+	server := &http.Server{
+		Addr:              addr,
+		Handler:           mux,
+		ReadHeaderTimeout: CheckServerReadHeaderTimeout,
+		ReadTimeout:       CheckServerReadTimeout,
+		WriteTimeout:      CheckServerWriteTimeout,
+		IdleTimeout:       CheckServerIdleTimeout,
+	}
+
+	if err := server.ListenAndServe(); err != nil {
+		log.Fatalf("error serving checks: %s", err.Error())
+	}
+}
+
+// Evaluate an object from the caches. The path is /check/{type}/{namespace}/{name}, or /check/{type}/{name}
+// for objects that are not namespaced.
+func (c *Controller) handleCheck(w http.ResponseWriter, r *http.Request) {
+	if !c.authorized(r) {
+		w.Header().Set("WWW-Authenticate", "Bearer")
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var typ, namespace, name string
+
+	parts := strings.Split(strings.Trim(strings.TrimPrefix(r.URL.Path, "/check/"), "/"), "/")
+	switch len(parts) {
+	case 2:
+		typ, name = parts[0], parts[1]
+	case 3:
+		typ, namespace, name = parts[0], parts[1], parts[2]
+	default:
+		http.Error(w, "expected /check/{type}/{namespace}/{name}", http.StatusBadRequest)
+		return
+	}
+
+	o, err := c.checkedObject(typ, namespace, name)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.IsNotFound(err) {
+			status = http.StatusNotFound
+		} else if errors.IsBadRequest(err) {
+			status = http.StatusBadRequest
+		}
+		writeCheckResponse(w, status, health.Result{State: health.Unknown, Output: err.Error()})
+		return
+	}
+
+	writeCheckResponse(w, http.StatusOK, c.evaluate(o))
+}
+
+// Requests must send our token as bearer token.
+func (c *Controller) authorized(r *http.Request) bool {
+	if c.CheckToken == "" {
+		return false
+	}
+	token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+	return subtle.ConstantTimeCompare([]byte(token), []byte(c.CheckToken)) == 1
+}
+
+// Get the object to check. The types are the same we use for VarType.
+func (c *Controller) checkedObject(typ, namespace, name string) (interface{}, error) {
+	switch typ {
+	case "pod":
+		return c.PodLister.Pods(namespace).Get(name)
+	case "deployment":
+		return c.DeploymentLister.Deployments(namespace).Get(name)
+	case "daemonset":
+		return c.DaemonSetLister.DaemonSets(namespace).Get(name)
+	case "replicaset":
+		return c.ReplicaSetLister.ReplicaSets(namespace).Get(name)
+	case "statefulset":
+		return c.StatefulSetLister.StatefulSets(namespace).Get(name)
+	case "horizontalpodautoscaler":
+		if c.HorizontalPodAutoscalerLister == nil {
+			return nil, errors.NewBadRequest("the cluster does not serve horizontalpodautoscalers")
+		}
+		return c.HorizontalPodAutoscalerLister.HorizontalPodAutoscalers(namespace).Get(name)
+	case "poddisruptionbudget":
+		if c.PodDisruptionBudgetLister == nil {
+			return nil, errors.NewBadRequest("the cluster does not serve poddisruptionbudgets")
+		}
+		return c.PodDisruptionBudgetLister.PodDisruptionBudgets(namespace).Get(name)
+	case "job":
+		return c.JobLister.Jobs(namespace).Get(name)
+	case "cronjob":
+		if c.CronJobLister == nil {
+			return nil, errors.NewBadRequest("the cluster does not serve cronjobs")
+		}
+		return c.CronJobLister.CronJobs(namespace).Get(name)
+	case "service":
+		return c.ServiceLister.Services(namespace).Get(name)
+	case "resourcequota":
+		return c.ResourceQuotaLister.ResourceQuotas(namespace).Get(name)
+	case "secret":
+		if c.SecretLister == nil {
+			return nil, errors.NewBadRequest("tls checks are not enabled")
+		}
+		return c.SecretLister.Secrets(namespace).Get(name)
+	case "helmrelease":
+		if c.HelmSecretLister == nil {
+			return nil, errors.NewBadRequest("helm release checks are not enabled")
+		}
+		return c.helmRelease(namespace, name)
+	case "persistentvolumeclaim":
+		return c.PersistentVolumeClaimLister.PersistentVolumeClaims(namespace).Get(name)
+	case "ingress":
+		if c.IngressLister == nil {
+			return nil, errors.NewBadRequest("the cluster does not serve ingresses")
+		}
+		return c.IngressLister.Ingresses(namespace).Get(name)
+	case "node":
+		return c.NodeLister.Get(name)
+	case "persistentvolume":
+		return c.PersistentVolumeLister.Get(name)
+	case "apiservice":
+		return c.APIServiceLister.Get(name)
+	case "validatingwebhookconfiguration":
+		return c.ValidatingWebhookConfigurationLister.Get(name)
+	case "mutatingwebhookconfiguration":
+		return c.MutatingWebhookConfigurationLister.Get(name)
+	case "componentstatus":
+		// Not cached, as they cannot be watched.
+		return c.Kubernetes.CoreV1().ComponentStatuses().Get(name, metav1.GetOptions{})
+	default:
+		if r := c.monitoredResourceByType(typ); r != nil {
+			return r.lister.ByNamespace(namespace).Get(name)
+		}
+		return nil, errors.NewBadRequest(fmt.Sprintf("cannot check objects of type '%s'", typ))
+	}
+}
+
+// The URL of the check endpoint for an object, as seen by Icinga.
+func (c *Controller) checkURL(typ, namespace, name string) string {
+	url := strings.TrimSuffix(c.CheckURL, "/") + "/check/" + typ
+	if namespace != "" {
+		url += "/" + namespace
+	}
+	return url + "/" + name
+}
+
+func writeCheckResponse(w http.ResponseWriter, status int, r health.Result) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	perfData := r.PerfData
+	if perfData == nil {
+		perfData = []string{}
+	}
+
+	err := json.NewEncoder(w).Encode(CheckResponse{
+		State:      r.State.String(),
+		ExitStatus: int(r.State),
+		Output:     r.Output,
+		PerfData:   perfData,
+	})
+	if err != nil {
+		log.Errorf("error writing check response: %s", err.Error())
+	}
+}
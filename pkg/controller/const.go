@@ -1,5 +1,7 @@
 package main
 
+import "time"
+
 const (
 
 	// This Icinga2 Var contains the cluster name.
@@ -17,6 +19,14 @@ const (
 	// Namespace/Name of the custom resource that owns this icinga object
 	VarOwner = "kubernetes_owner"
 
+	// URL of the check endpoint for the monitored object.
+	VarCheckURL = "kubernetes_check_url"
+
+	// The chart, chart version and revision of a Helm release.
+	VarHelmChart        = "kubernetes_helm_chart"
+	VarHelmChartVersion = "kubernetes_helm_chart_version"
+	VarHelmRevision     = "kubernetes_helm_revision"
+
 	// Disable monitoring
 	AnnDisableMonitoring = "icinga.nexinto.com/nomonitoring"
 
@@ -26,5 +36,43 @@ const (
 	// NotesURL
 	AnnNotesURL = "icinga.nexinto.com/notesurl"
 
+	// Create http checks for the hosts and paths of an Ingress
+	AnnHTTPChecks = "icinga.nexinto.com/httpchecks"
+
+	// Check the certificate of a TLS Secret, or of all TLS Secrets in a namespace
+	AnnTLSCheck = "icinga.nexinto.com/tlscheck"
+
+	// Create a check for every member of a StatefulSet or for every node of a DaemonSet
+	AnnReplicaChecks = "icinga.nexinto.com/replicachecks"
+
+	// Usage in percent at which usage checks warn
+	AnnUsageWarning = "icinga.nexinto.com/usage-warning"
+
+	// Usage in percent at which usage checks become critical
+	AnnUsageCritical = "icinga.nexinto.com/usage-critical"
+
+	// The source component of the events we create.
+	EventSource = "kubernetes-icinga"
+
 	EMPTY = "<EMPTY>"
+
+	// Icinga runs check_kubernetes to check the state of objects.
+	CheckModeActive = "active"
+
+	// We evaluate the state of objects and send the results to Icinga.
+	CheckModePassive = "passive"
+
+	// Icinga queries the state of objects from our HTTP check endpoint.
+	CheckModeHTTP = "http"
+
+	// How often and after how long a check result for an Icinga object that does not exist yet is retried. The
+	// delay doubles with every attempt.
+	CheckResultRetries    = 5
+	CheckResultRetryDelay = 2 * time.Second
+
+	// Timeouts of the HTTP check endpoint. Check requests are small and answered from the caches.
+	CheckServerReadHeaderTimeout = 10 * time.Second
+	CheckServerReadTimeout       = 30 * time.Second
+	CheckServerWriteTimeout      = 30 * time.Second
+	CheckServerIdleTimeout       = 120 * time.Second
 )
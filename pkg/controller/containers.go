@@ -0,0 +1,164 @@
+package main
+
+import (
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/Nexinto/kubernetes-icinga/pkg/health"
+)
+
+// Remembers the restart counts of containers by pod UID and container name, so restarts within a window can be
+// counted.
+type restartTracker struct {
+	sync.Mutex
+	samples map[string][]restartSample
+}
+
+type restartSample struct {
+	time  time.Time
+	count int32
+}
+
+// Record the restart count of a container and return the number of restarts within the window.
+func (t *restartTracker) observe(key string, count int32, window time.Duration) int32 {
+	now := time.Now()
+
+	t.Lock()
+	defer t.Unlock()
+
+	if t.samples == nil {
+		t.samples = make(map[string][]restartSample)
+	}
+
+	// The oldest sample we keep is the newest one from before the window.
+	samples := append(t.samples[key], restartSample{time: now, count: count})
+	for len(samples) > 1 && now.Sub(samples[1].time) >= window {
+		samples = samples[1:]
+	}
+	t.samples[key] = samples
+
+	if restarts := count - samples[0].count; restarts > 0 {
+		return restarts
+	}
+	return 0
+}
+
+// Forget the containers of pods that no longer exist.
+func (t *restartTracker) retain(seen map[string]bool) {
+	t.Lock()
+	defer t.Unlock()
+
+	for key := range t.samples {
+		if !seen[key] {
+			delete(t.samples, key)
+		}
+	}
+}
+
+// Check the containers of the pods of Deployments, StatefulSets, DaemonSets and ReplicaSets once a minute. The
+// results are always sent as passive check results.
+func (c *Controller) RefreshContainers() {
+	for {
+		c.refreshContainers()
+		time.Sleep(60 * time.Second)
+	}
+}
+
+func (c *Controller) refreshContainers() {
+	pods, err := c.PodLister.List(labels.Everything())
+	if err != nil {
+		log.Errorf("error listing pods: %s", err.Error())
+		return
+	}
+
+	owned := make(map[types.UID][]*corev1.Pod)
+	restarts := make(map[string]int32)
+	seen := make(map[string]bool)
+
+	for _, pod := range pods {
+		if ref := metav1.GetControllerOf(pod); ref != nil {
+			owned[ref.UID] = append(owned[ref.UID], pod)
+		}
+		for _, statuses := range [][]corev1.ContainerStatus{pod.Status.InitContainerStatuses, pod.Status.ContainerStatuses} {
+			for _, cs := range statuses {
+				key := string(pod.UID) + "/" + cs.Name
+				seen[key] = true
+				restarts[key] = c.Restarts.observe(key, cs.RestartCount, c.RestartWindow)
+			}
+		}
+	}
+
+	c.Restarts.retain(seen)
+
+	replicasets, err := c.ReplicaSetLister.List(labels.Everything())
+	if err != nil {
+		log.Errorf("error listing replicasets: %s", err.Error())
+		return
+	}
+
+	for _, rs := range replicasets {
+		if ref := metav1.GetControllerOf(rs); ref != nil {
+			owned[ref.UID] = append(owned[ref.UID], owned[rs.UID]...)
+		} else {
+			c.processContainers(rs, "rs", "ReplicaSet", "replicaset", owned[rs.UID], restarts)
+		}
+	}
+
+	if deployments, err := c.DeploymentLister.List(labels.Everything()); err == nil {
+		for _, d := range deployments {
+			c.processContainers(d, "deploy", "Deployment", "deployment", owned[d.UID], restarts)
+		}
+	} else {
+		log.Errorf("error listing deployments: %s", err.Error())
+	}
+
+	if statefulsets, err := c.StatefulSetLister.List(labels.Everything()); err == nil {
+		for _, ss := range statefulsets {
+			c.processContainers(ss, "statefulset", "StatefulSet", "statefulset", owned[ss.UID], restarts)
+		}
+	} else {
+		log.Errorf("error listing statefulsets: %s", err.Error())
+	}
+
+	if daemonsets, err := c.DaemonSetLister.List(labels.Everything()); err == nil {
+		for _, ds := range daemonsets {
+			c.processContainers(ds, "ds", "DaemonSet", "daemonset", owned[ds.UID], restarts)
+		}
+	} else {
+		log.Errorf("error listing daemonsets: %s", err.Error())
+	}
+}
+
+// Create or remove the containers check of a workload.
+func (c *Controller) processContainers(o metav1.Object, abbrev, kind, typ string, pods []*corev1.Pod, restarts map[string]int32) {
+	// Workload owned by something else is never monitored.
+	if len(o.GetOwnerReferences()) > 0 {
+		return
+	}
+
+	meta := MakeObjectMeta(o, kind, "apps/v1", abbrev, false)
+	meta.Name += "-containers"
+
+	if !c.monitored(o) || o.GetDeletionTimestamp() != nil {
+		if _, err := c.CheckLister.Checks(meta.Namespace).Get(meta.Name); err == nil {
+			if err := c.deleteCheck(meta.Namespace, meta.Name); err != nil {
+				log.Errorf("error deleting containers check for %s '%s/%s': %s", typ, o.GetNamespace(), o.GetName(), err.Error())
+			}
+		}
+		return
+	}
+
+	r := health.Containers(pods, restarts, c.RestartWarning, c.RestartWindow, c.PodPendingGrace)
+	host, name := c.Mapping.AttachedCheck(c, o, abbrev, "containers")
+
+	if err := c.passiveCheck(meta, host, name, c.MakeVars(o, typ, true), r); err != nil {
+		log.Errorf("error creating containers check for %s '%s/%s': %s", typ, o.GetNamespace(), o.GetName(), err.Error())
+	}
+}
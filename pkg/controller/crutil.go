@@ -11,8 +11,9 @@ import (
 func (c *Controller) reconcileHostGroup(hostgroup *icingav1.HostGroup) error {
 	ohg, err := c.IcingaClient.IcingaV1().HostGroups(hostgroup.Namespace).Get(hostgroup.Name, metav1.GetOptions{})
 	if err == nil {
-		if !reflect.DeepEqual(ohg.Spec, hostgroup.Spec) {
+		if !reflect.DeepEqual(ohg.Spec, hostgroup.Spec) || !reflect.DeepEqual(ohg.OwnerReferences, hostgroup.OwnerReferences) {
 			hostgroup.Spec.DeepCopyInto(&ohg.Spec)
+			ohg.OwnerReferences = hostgroup.OwnerReferences
 			log.Infof("updating hostgroup cr '%s/%s'", ohg.Namespace, ohg.Name)
 			_, err := c.IcingaClient.IcingaV1().HostGroups(ohg.Namespace).Update(ohg)
 			if err != nil {
@@ -51,8 +52,9 @@ func (c *Controller) deleteHostGroup(namespace, name string) error {
 func (c *Controller) reconcileHost(host *icingav1.Host) error {
 	oh, err := c.IcingaClient.IcingaV1().Hosts(host.Namespace).Get(host.Name, metav1.GetOptions{})
 	if err == nil {
-		if !reflect.DeepEqual(oh.Spec, host.Spec) {
+		if !reflect.DeepEqual(oh.Spec, host.Spec) || !reflect.DeepEqual(oh.OwnerReferences, host.OwnerReferences) {
 			host.Spec.DeepCopyInto(&oh.Spec)
+			oh.OwnerReferences = host.OwnerReferences
 			log.Infof("updating host cr '%s/%s'", oh.Namespace, oh.Name)
 			_, err := c.IcingaClient.IcingaV1().Hosts(oh.Namespace).Update(oh)
 			if err != nil {
@@ -91,8 +93,9 @@ func (c *Controller) deleteHost(namespace, name string) error {
 func (c *Controller) reconcileCheck(check *icingav1.Check) error {
 	oc, err := c.IcingaClient.IcingaV1().Checks(check.Namespace).Get(check.Name, metav1.GetOptions{})
 	if err == nil {
-		if !reflect.DeepEqual(check.Spec, oc.Spec) {
+		if !reflect.DeepEqual(check.Spec, oc.Spec) || !reflect.DeepEqual(check.OwnerReferences, oc.OwnerReferences) {
 			check.Spec.DeepCopyInto(&oc.Spec)
+			oc.OwnerReferences = check.OwnerReferences
 			log.Infof("updating check cr '%s/%s'", oc.Namespace, oc.Name)
 			_, err := c.IcingaClient.IcingaV1().Checks(oc.Namespace).Update(oc)
 			if err != nil {
@@ -127,3 +130,12 @@ func (c *Controller) deleteCheck(namespace, name string) error {
 		return err
 	}
 }
+
+// Delete a check cr only if the cache has it. For checks that would otherwise be deleted again on every sync of an
+// object that is not monitored.
+func (c *Controller) deleteCachedCheck(namespace, name string) error {
+	if _, err := c.CheckLister.Checks(namespace).Get(name); err != nil {
+		return nil
+	}
+	return c.deleteCheck(namespace, name)
+}
@@ -0,0 +1,117 @@
+package main
+
+import (
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/Nexinto/kubernetes-icinga/pkg/health"
+)
+
+// Check for namespaces, pods, our own objects and monitored resources that have been deleted, but still exist after
+// StuckDeletionGrace. The result is always sent as a passive check result.
+func (c *Controller) RefreshStuckDeletions() {
+	for {
+		c.refreshStuckDeletions()
+		time.Sleep(60 * time.Second)
+	}
+}
+
+func (c *Controller) refreshStuckDeletions() {
+	if err := c.Mapping.MonitorClusterChecks(c); err != nil {
+		log.Errorf("error creating host for cluster checks: %s", err.Error())
+		return
+	}
+
+	host, name := c.Mapping.ClusterCheck(c, "stuck-deletions")
+	meta := metav1.ObjectMeta{Name: "stuck-deletions", Namespace: "kube-system"}
+	vars := c.MakeVars(&meta, "cluster", false)
+
+	if err := c.passiveCheck(meta, host, name, vars, health.StuckDeletions(c.deletions(), c.StuckDeletionGrace)); err != nil {
+		log.Errorf("error creating stuck deletions check: %s", err.Error())
+	}
+}
+
+// All objects we know of that have a deletion timestamp.
+func (c *Controller) deletions() []health.Deletion {
+	var deletions []health.Deletion
+
+	add := func(kind string, o metav1.Object, finalizers ...string) {
+		if ts := o.GetDeletionTimestamp(); ts != nil {
+			deletions = append(deletions, health.Deletion{
+				Kind:       kind,
+				Namespace:  o.GetNamespace(),
+				Name:       o.GetName(),
+				Since:      ts.Time,
+				Finalizers: append(finalizers, o.GetFinalizers()...),
+			})
+		}
+	}
+
+	if namespaces, err := c.NamespaceLister.List(labels.Everything()); err == nil {
+		for _, namespace := range namespaces {
+			// The finalizers in the spec block the deletion of a namespace until its content is gone.
+			var finalizers []string
+			for _, f := range namespace.Spec.Finalizers {
+				finalizers = append(finalizers, string(f))
+			}
+			add("Namespace", namespace, finalizers...)
+		}
+	} else {
+		log.Errorf("error listing namespaces: %s", err.Error())
+	}
+
+	if pods, err := c.PodLister.List(labels.Everything()); err == nil {
+		for _, pod := range pods {
+			add("Pod", pod)
+		}
+	} else {
+		log.Errorf("error listing pods: %s", err.Error())
+	}
+
+	if hostgroups, err := c.HostGroupLister.List(labels.Everything()); err == nil {
+		for _, hg := range hostgroups {
+			add("HostGroup", hg)
+		}
+	} else {
+		log.Errorf("error listing hostgroups: %s", err.Error())
+	}
+
+	if hosts, err := c.HostLister.List(labels.Everything()); err == nil {
+		for _, host := range hosts {
+			add("Host", host)
+		}
+	} else {
+		log.Errorf("error listing hosts: %s", err.Error())
+	}
+
+	if checks, err := c.CheckLister.List(labels.Everything()); err == nil {
+		for _, check := range checks {
+			add("Check", check)
+		}
+	} else {
+		log.Errorf("error listing checks: %s", err.Error())
+	}
+
+	for _, r := range c.MonitoredResources {
+		if r.lister == nil {
+			continue
+		}
+		objects, err := r.lister.List(labels.Everything())
+		if err != nil {
+			log.Errorf("error listing %s: %s", r.GroupVersionResource(), err.Error())
+			continue
+		}
+		for _, obj := range objects {
+			if o, err := apimeta.Accessor(obj); err == nil {
+				add(r.Kind, o)
+			}
+		}
+	}
+
+	return deletions
+}
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/discovery"
+)
+
+// The resources we watch, by group version. Informers for resources the cluster does not serve never sync.
+var requiredResources = map[string][]string{
+	"v1":                              {"pods", "nodes", "namespaces", "services", "endpoints", "secrets", "persistentvolumeclaims", "persistentvolumes", "resourcequotas"},
+	"apps/v1":                         {"deployments", "daemonsets", "replicasets", "statefulsets"},
+	"batch/v1":                        {"jobs"},
+	"apiregistration.k8s.io/v1":       {"apiservices"},
+	"admissionregistration.k8s.io/v1": {"validatingwebhookconfigurations", "mutatingwebhookconfigurations"},
+}
+
+// Only polled with COMPONENT_STATUSES. They are deprecated and clusters may stop serving them.
+var componentStatusResources = map[string][]string{
+	"v1": {"componentstatuses"},
+}
+
+// Check that the cluster serves all resources we watch, and ComponentStatuses if we poll them.
+func CheckAPIResources(d discovery.DiscoveryInterface, componentStatuses bool) error {
+	missing, err := missingResources(d, requiredResources)
+	if err != nil {
+		return err
+	}
+
+	if componentStatuses {
+		m, err := missingResources(d, componentStatusResources)
+		if err != nil {
+			return err
+		}
+		missing = append(missing, m...)
+	}
+
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		return fmt.Errorf("the cluster does not serve %s", strings.Join(missing, ", "))
+	}
+
+	return nil
+}
+
+// The resources, as group/version/resource, that the cluster does not serve.
+func missingResources(d discovery.DiscoveryInterface, resources map[string][]string) ([]string, error) {
+	var missing []string
+
+	for groupVersion, names := range resources {
+		served := make(map[string]bool)
+
+		list, err := d.ServerResourcesForGroupVersion(groupVersion)
+		if err != nil && !errors.IsNotFound(err) {
+			return nil, fmt.Errorf("error discovering resources for %s: %s", groupVersion, err.Error())
+		}
+		if list != nil {
+			for _, r := range list.APIResources {
+				served[r.Name] = true
+			}
+		}
+
+		for _, r := range names {
+			if !served[r] {
+				missing = append(missing, groupVersion+"/"+r)
+			}
+		}
+	}
+
+	return missing, nil
+}
+
+// True if the cluster serves a resource. Some resources are watched in API versions that newer Kubernetes versions
+// removed; there, they are not monitored.
+func servesResource(d discovery.DiscoveryInterface, groupVersion, resource string) bool {
+	missing, err := missingResources(d, map[string][]string{groupVersion: {resource}})
+	if err != nil {
+		log.Errorf("not monitoring %s/%s: %s", groupVersion, resource, err.Error())
+		return false
+	}
+	if len(missing) > 0 {
+		log.Warnf("the cluster does not serve %s, not monitoring %s", missing[0], resource)
+		return false
+	}
+	return true
+}
@@ -0,0 +1,121 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/wait"
+	kubernetesinformers "k8s.io/client-go/informers"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+
+	"github.com/Nexinto/kubernetes-icinga/pkg/health"
+)
+
+// Set up an informer for Warning events. It has its own factory, so only Warning events are cached. The queue
+// contains the names of namespaces whose events changed, not the events.
+func (c *Controller) InitializeEvents() {
+	c.EventFactory = kubernetesinformers.NewSharedInformerFactoryWithOptions(c.Kubernetes, time.Second*60,
+		kubernetesinformers.WithTweakListOptions(func(options *metav1.ListOptions) {
+			options.FieldSelector = fields.OneTermEqualSelector("type", corev1.EventTypeWarning).String()
+		}))
+
+	informer := c.EventFactory.Core().V1().Events()
+	c.EventQueue = workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
+	c.EventLister = informer.Lister()
+	c.EventSynced = informer.Informer().HasSynced
+
+	// Bursts of events in a namespace are summarized once. Events that leave the window are taken care of
+	// by RefreshEvents.
+	queue := func(obj interface{}) {
+		if e, ok := obj.(*corev1.Event); ok && c.eventCounted(e) {
+			c.EventQueue.AddAfter(e.Namespace, 10*time.Second)
+		}
+	}
+
+	informer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: queue,
+		UpdateFunc: func(old, new interface{}) {
+			queue(new)
+		},
+	})
+}
+
+func (c *Controller) RunEvents(stopCh <-chan struct{}) {
+	go wait.Until(c.RefreshEvents, 60*time.Second, stopCh)
+	runQueue(c.EventFactory, c.EventSynced, c.EventQueue, c.processNamespaceEvents, stopCh)
+}
+
+// Summarize the events of all namespaces again, as events leave the window.
+func (c *Controller) RefreshEvents() {
+	namespaces, err := c.NamespaceLister.List(labels.Everything())
+	if err != nil {
+		log.Errorf("error listing namespaces: %s", err.Error())
+		return
+	}
+
+	for _, namespace := range namespaces {
+		c.EventQueue.Add(namespace.Name)
+	}
+}
+
+// Monitored namespaces get a check named events that summarizes the Warning events within EventWindow. The result
+// is always sent as a passive check result.
+func (c *Controller) processNamespaceEvents(name string) error {
+	namespace, err := c.NamespaceLister.Get(name)
+	if errors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("error getting namespace %s from cache: %s", name, err.Error())
+	}
+
+	if !c.monitored(namespace) || namespace.GetDeletionTimestamp() != nil {
+		return c.deleteCachedCheck(namespace.Name, "events")
+	}
+
+	if err := c.Mapping.MonitorNamespaceChecks(c, namespace); err != nil {
+		return err
+	}
+
+	all, err := c.EventLister.Events(namespace.Name).List(labels.Everything())
+	if err != nil {
+		return err
+	}
+
+	var events []*corev1.Event
+	for _, e := range all {
+		if c.eventCounted(e) {
+			events = append(events, e)
+		}
+	}
+
+	meta := metav1.ObjectMeta{
+		Name:            "events",
+		Namespace:       namespace.Name,
+		OwnerReferences: MakeOwnerRef(namespace, "Namespace", "v1"),
+	}
+	host, check := c.Mapping.NamespaceCheck(c, namespace, "events")
+
+	r := health.Events(namespace.Name, events, time.Now().Add(-c.EventWindow), c.EventWarning, c.EventCritical)
+	return c.passiveCheck(meta, host, check, c.MakeVars(namespace, "events", false), r)
+}
+
+// Our own events and events with an ignored reason are not counted.
+func (c *Controller) eventCounted(e *corev1.Event) bool {
+	if e.Source.Component == EventSource {
+		return false
+	}
+	for _, reason := range c.EventIgnoredReasons {
+		if e.Reason == reason {
+			return false
+		}
+	}
+	return true
+}
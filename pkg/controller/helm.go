@@ -0,0 +1,146 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	kubernetesinformers "k8s.io/client-go/informers"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+
+	"github.com/Nexinto/kubernetes-icinga/pkg/health"
+)
+
+// The type of the Secrets Helm 3 stores its releases in.
+const helmReleaseSecretType = "helm.sh/release.v1"
+
+// A Helm release, as recorded in the Secret of its latest revision.
+type helmRelease struct {
+	metav1.ObjectMeta
+
+	Secret  *corev1.Secret
+	Release *health.HelmRelease
+
+	// Set if the release record cannot be decoded.
+	Err error
+}
+
+func (r *helmRelease) Owner() metav1.Object {
+	return r.Secret
+}
+
+func (r *helmRelease) Vars() map[string]string {
+	if r.Release == nil {
+		return map[string]string{}
+	}
+	return map[string]string{
+		VarHelmChart:        r.Release.Chart.Metadata.Name,
+		VarHelmChartVersion: r.Release.Chart.Metadata.Version,
+		VarHelmRevision:     strconv.Itoa(r.Release.Version),
+	}
+}
+
+// Set up an informer for Helm release Secrets. It has its own factory, so only Secrets of type helm.sh/release.v1
+// are cached. The queue contains namespace/release keys, not the keys of the Secrets.
+func (c *Controller) InitializeHelmReleases() {
+	c.HelmFactory = kubernetesinformers.NewSharedInformerFactoryWithOptions(c.Kubernetes, time.Second*60,
+		kubernetesinformers.WithTweakListOptions(func(options *metav1.ListOptions) {
+			options.FieldSelector = fields.OneTermEqualSelector("type", helmReleaseSecretType).String()
+		}))
+
+	informer := c.HelmFactory.Core().V1().Secrets()
+	c.HelmQueue = workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
+	c.HelmSecretLister = informer.Lister()
+	c.HelmSynced = informer.Informer().HasSynced
+
+	queue := func(obj interface{}) {
+		if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+			obj = tombstone.Obj
+		}
+		if secret, ok := obj.(*corev1.Secret); ok && secret.Labels["name"] != "" {
+			c.HelmQueue.Add(secret.Namespace + "/" + secret.Labels["name"])
+		}
+	}
+
+	informer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: queue,
+		UpdateFunc: func(old, new interface{}) {
+			queue(new)
+		},
+		DeleteFunc: queue,
+	})
+}
+
+func (c *Controller) RunHelmReleases(stopCh <-chan struct{}) {
+	runQueue(c.HelmFactory, c.HelmSynced, c.HelmQueue, c.processHelmRelease, stopCh)
+}
+
+func (c *Controller) processHelmRelease(key string) error {
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		return fmt.Errorf("could not parse name %s: %s", key, err.Error())
+	}
+
+	release, err := c.helmRelease(namespace, name)
+	if errors.IsNotFound(err) {
+		return c.HelmReleaseDeleted(namespace, name)
+	} else if err != nil {
+		return err
+	}
+
+	return c.HelmReleaseCreatedOrUpdated(release)
+}
+
+// Helm releases are monitored like workload.
+func (c *Controller) HelmReleaseCreatedOrUpdated(release *helmRelease) error {
+	return c.processWorkload(release, "helm", "helmrelease", "Secret", "v1")
+}
+
+func (c *Controller) HelmReleaseDeleted(namespace, name string) error {
+	log.Debugf("processing deleted helm release '%s/%s'", namespace, name)
+	return c.Mapping.UnmonitorWorkload(c, &metav1.ObjectMeta{Name: name, Namespace: namespace}, "helm")
+}
+
+// The latest revision of a Helm release.
+func (c *Controller) helmRelease(namespace, name string) (*helmRelease, error) {
+	secrets, err := c.HelmSecretLister.Secrets(namespace).List(labels.SelectorFromSet(labels.Set{"owner": "helm", "name": name}))
+	if err != nil {
+		return nil, err
+	}
+
+	var latest *corev1.Secret
+	revision := -1
+	for _, secret := range secrets {
+		if secret.GetDeletionTimestamp() != nil {
+			continue
+		}
+		if v, err := strconv.Atoi(secret.Labels["version"]); err == nil && v > revision {
+			latest, revision = secret, v
+		}
+	}
+
+	if latest == nil {
+		return nil, errors.NewNotFound(schema.GroupResource{Resource: "helmreleases"}, name)
+	}
+
+	release := &helmRelease{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Namespace:   namespace,
+			Annotations: latest.Annotations,
+		},
+		Secret: latest,
+	}
+	release.Release, release.Err = health.DecodeHelmRelease(latest)
+
+	return release, nil
+}
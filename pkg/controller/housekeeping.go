@@ -8,6 +8,7 @@ import (
 
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corelisterv1 "k8s.io/client-go/listers/core/v1"
 	"k8s.io/client-go/tools/cache"
 )
 
@@ -184,6 +185,8 @@ func (c *Controller) CrHousekeeping() {
 			}
 			or := h.OwnerReferences[0]
 
+			// Hosts created by older versions reference workload in extensions/v1beta1 or apps/v1beta2;
+			// only the kind matters here.
 			switch or.Kind {
 			case "Pod":
 				_, err = c.PodLister.Pods(h.Namespace).Get(or.Name)
@@ -193,12 +196,61 @@ func (c *Controller) CrHousekeeping() {
 				_, err = c.DaemonSetLister.DaemonSets(h.Namespace).Get(or.Name)
 			case "ReplicaSet":
 				_, err = c.ReplicaSetLister.ReplicaSets(h.Namespace).Get(or.Name)
+			case "Service":
+				_, err = c.ServiceLister.Services(h.Namespace).Get(or.Name)
+			case "PodDisruptionBudget":
+				// Not watched if the cluster does not serve them.
+				err = nil
+				if c.PodDisruptionBudgetLister != nil {
+					_, err = c.PodDisruptionBudgetLister.PodDisruptionBudgets(h.Namespace).Get(or.Name)
+				}
+			case "Job":
+				_, err = c.JobLister.Jobs(h.Namespace).Get(or.Name)
+			case "CronJob":
+				// Not watched if the cluster does not serve them.
+				err = nil
+				if c.CronJobLister != nil {
+					_, err = c.CronJobLister.CronJobs(h.Namespace).Get(or.Name)
+				}
+			case "Ingress":
+				// Not watched if the cluster does not serve them.
+				err = nil
+				if c.IngressLister != nil {
+					_, err = c.IngressLister.Ingresses(h.Namespace).Get(or.Name)
+				}
 			case "StatefulSet":
 				_, err = c.StatefulSetLister.StatefulSets(h.Namespace).Get(or.Name)
+			case "ResourceQuota":
+				_, err = c.ResourceQuotaLister.ResourceQuotas(h.Namespace).Get(or.Name)
+			case "Secret":
+				// TLS Secrets and Helm releases are cached separately, if at all.
+				err = nil
+				for _, lister := range []corelisterv1.SecretLister{c.SecretLister, c.HelmSecretLister} {
+					if lister == nil {
+						continue
+					}
+					if _, err = lister.Secrets(h.Namespace).Get(or.Name); err == nil {
+						break
+					}
+				}
+			case "PersistentVolumeClaim":
+				_, err = c.PersistentVolumeClaimLister.PersistentVolumeClaims(h.Namespace).Get(or.Name)
+			case "PersistentVolume":
+				_, err = c.PersistentVolumeLister.Get(or.Name)
 			case "Node":
 				_, err = c.NodeLister.Get(or.Name)
+			case "APIService":
+				_, err = c.APIServiceLister.Get(or.Name)
+			case "ValidatingWebhookConfiguration":
+				_, err = c.ValidatingWebhookConfigurationLister.Get(or.Name)
+			case "MutatingWebhookConfiguration":
+				_, err = c.MutatingWebhookConfigurationLister.Get(or.Name)
 			default:
-				err = nil
+				if r := c.monitoredResourceByKind(or.APIVersion, or.Kind); r != nil {
+					_, err = r.lister.ByNamespace(h.Namespace).Get(or.Name)
+				} else {
+					err = nil
+				}
 			}
 			if errors.IsNotFound(err) {
 				log.Infof("[crhousekeeping] deleting obsolete host '%s/%s' (owner %s '%s/%s' no longer exists)", h.Namespace, h.Name, or.Kind, h.Namespace, or.Name)
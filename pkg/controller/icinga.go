@@ -10,16 +10,24 @@ import (
 )
 
 func (c *Controller) HostGroupCreatedOrUpdated(hostgroup *icingav1.HostGroup) error {
+	name := c.Tag + empty(hostgroup.Spec.Name)
+	written, err := c.syncHostGroup(hostgroup, name)
+	c.updateHostGroupStatus(hostgroup, name, written, err)
+	return err
+}
+
+// Create or update the Icinga hostgroup. Returns true if something was written to Icinga.
+func (c *Controller) syncHostGroup(hostgroup *icingav1.HostGroup, name string) (bool, error) {
 	owner := fmt.Sprintf("%s/%s", hostgroup.Namespace, hostgroup.Name)
 	log.Debugf("processing hostgroup '%s'", owner)
 	newHg := icinga2.HostGroup{
-		Name: c.Tag + empty(hostgroup.Spec.Name),
+		Name: name,
 		Vars: Vars(mergeVars(c.DefaultVars, hostgroup.Spec.Vars, map[string]string{VarCluster: c.Tag, VarOwner: owner})),
 	}
 	hg, err := c.Icinga.GetHostGroup(newHg.Name)
 	if err == nil {
 		if hg.Vars[VarCluster] != c.Tag {
-			return fmt.Errorf("cannot update hostgroup '%s': it is not managed by us ('%s')", newHg.Name, hg.Vars[VarCluster])
+			return false, &NotManagedError{Kind: "hostgroup", Name: newHg.Name, Owner: hg.Vars[VarCluster]}
 		}
 
 		if hg.Name != newHg.Name || varsDiffer(hg.Vars, newHg.Vars) {
@@ -31,7 +39,7 @@ func (c *Controller) HostGroupCreatedOrUpdated(hostgroup *icingav1.HostGroup) er
 			} else {
 				MakeEvent(c.Kubernetes, hostgroup, "hostgroup updated", "HostGroup", false)
 			}
-			return err
+			return err == nil, err
 		}
 	} else {
 		log.Infof("creating icinga hostgroup '%s'", newHg.Name)
@@ -42,9 +50,9 @@ func (c *Controller) HostGroupCreatedOrUpdated(hostgroup *icingav1.HostGroup) er
 		} else {
 			MakeEvent(c.Kubernetes, hostgroup, "hostgroup created", "HostGroup", false)
 		}
-		return err
+		return err == nil, err
 	}
-	return nil
+	return false, nil
 }
 
 func (c *Controller) HostGroupDeleted(hostgroup *icingav1.HostGroup) error {
@@ -72,6 +80,14 @@ func (c *Controller) HostGroupDeleted(hostgroup *icingav1.HostGroup) error {
 }
 
 func (c *Controller) HostCreatedOrUpdated(host *icingav1.Host) error {
+	name := c.Tag + empty(host.Spec.Name)
+	written, err := c.syncHost(host, name)
+	c.updateHostStatus(host, name, written, err)
+	return err
+}
+
+// Create or update the Icinga host. Returns true if something was written to Icinga.
+func (c *Controller) syncHost(host *icingav1.Host, name string) (bool, error) {
 	owner := fmt.Sprintf("%s/%s", host.Namespace, host.Name)
 	log.Debugf("processing host '%s'", owner)
 
@@ -81,7 +97,7 @@ func (c *Controller) HostCreatedOrUpdated(host *icingav1.Host) error {
 	}
 
 	ih := icinga2.Host{
-		Name:         c.Tag + empty(host.Spec.Name),
+		Name:         name,
 		Groups:       hostgroups,
 		CheckCommand: host.Spec.CheckCommand,
 		Vars:         Vars(mergeVars(c.DefaultVars, host.Spec.Vars, map[string]string{VarCluster: c.Tag, VarOwner: owner})),
@@ -98,7 +114,7 @@ func (c *Controller) HostCreatedOrUpdated(host *icingav1.Host) error {
 	oh, err := c.Icinga.GetHost(ih.Name)
 	if err == nil {
 		if oh.Vars[VarCluster] != c.Tag {
-			return fmt.Errorf("cannot update host '%s': it is not managed by us ('%s')", ih.Name, oh.Vars[VarCluster])
+			return false, &NotManagedError{Kind: "host", Name: ih.Name, Owner: oh.Vars[VarCluster]}
 		}
 
 		if oh.Name != ih.Name ||
@@ -114,7 +130,7 @@ func (c *Controller) HostCreatedOrUpdated(host *icingav1.Host) error {
 			} else {
 				MakeEvent(c.Kubernetes, host, "host updated", "Host", false)
 			}
-			return err
+			return err == nil, err
 		}
 	} else {
 		log.Infof("creating icinga host '%s'", ih.Name)
@@ -125,9 +141,9 @@ func (c *Controller) HostCreatedOrUpdated(host *icingav1.Host) error {
 		} else {
 			MakeEvent(c.Kubernetes, host, "host created", "Host", false)
 		}
-		return err
+		return err == nil, err
 	}
-	return nil
+	return false, nil
 }
 
 func (c *Controller) HostDeleted(host *icingav1.Host) error {
@@ -155,9 +171,16 @@ func (c *Controller) HostDeleted(host *icingav1.Host) error {
 }
 
 func (c *Controller) CheckCreatedOrUpdated(check *icingav1.Check) error {
+	name := c.Tag + "." + check.Spec.Host + "!" + check.Spec.Name
+	written, err := c.syncCheck(check, name)
+	c.updateCheckStatus(check, name, written, err)
+	return err
+}
+
+// Create or update the Icinga service. Returns true if something was written to Icinga.
+func (c *Controller) syncCheck(check *icingav1.Check, name string) (bool, error) {
 	owner := fmt.Sprintf("%s/%s", check.Namespace, check.Name)
 	log.Debugf("processing check '%s'", owner)
-	name := c.Tag + "." + check.Spec.Host + "!" + check.Spec.Name
 
 	nc := icinga2.Service{
 		Name:         check.Spec.Name,
@@ -182,7 +205,7 @@ func (c *Controller) CheckCreatedOrUpdated(check *icingav1.Check) error {
 			} else {
 				MakeEvent(c.Kubernetes, check, "service updated", "Check", false)
 			}
-			return err
+			return err == nil, err
 		}
 	} else {
 		log.Infof("creating icinga check '%s'", nc.Name)
@@ -192,10 +215,10 @@ func (c *Controller) CheckCreatedOrUpdated(check *icingav1.Check) error {
 		} else {
 			MakeEvent(c.Kubernetes, check, "service created", "Check", false)
 		}
-		return err
+		return err == nil, err
 	}
 
-	return nil
+	return false, nil
 }
 
 func (c *Controller) CheckDeleted(check *icingav1.Check) error {
@@ -0,0 +1,208 @@
+package main
+
+import (
+	"bytes"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// Parts of the Icinga2 API that are not covered by the icinga2 client.
+type IcingaAPI interface {
+	// The current state of all hosts tagged with our cluster.
+	ListHostStates(tag string) ([]IcingaState, error)
+
+	// The current state of all services tagged with our cluster.
+	ListServiceStates(tag string) ([]IcingaState, error)
+
+	// Submit a passive check result for a host ("Host") or service ("Service"). If ttl is set, Icinga
+	// will run the freshness check if no new result arrives within ttl seconds.
+	ProcessCheckResult(typ, name string, exitStatus int, output string, perfData []string, ttl int) error
+}
+
+// The state of an Icinga host or service.
+type IcingaState struct {
+	Name            string
+	Owner           string
+	State           int
+	StateType       int
+	Checked         bool
+	Output          string
+	LastStateChange time.Time
+}
+
+type IcingaWebAPI struct {
+	URL      string
+	Username string
+	Password string
+	Debug    bool
+
+	client *http.Client
+}
+
+func NewIcingaWebAPI(url, username, password string, debug, insecureTLS bool) *IcingaWebAPI {
+	return &IcingaWebAPI{
+		URL:      url,
+		Username: username,
+		Password: password,
+		Debug:    debug,
+		client: &http.Client{
+			Timeout: 30 * time.Second,
+			Transport: &http.Transport{
+				TLSClientConfig: &tls.Config{InsecureSkipVerify: insecureTLS},
+			},
+		},
+	}
+}
+
+func (a *IcingaWebAPI) ListHostStates(tag string) ([]IcingaState, error) {
+	return a.queryStates("hosts", "host", tag)
+}
+
+func (a *IcingaWebAPI) ListServiceStates(tag string) ([]IcingaState, error) {
+	return a.queryStates("services", "service", tag)
+}
+
+func (a *IcingaWebAPI) ProcessCheckResult(typ, name string, exitStatus int, output string, perfData []string, ttl int) error {
+	body := map[string]interface{}{
+		"type":               typ,
+		strings.ToLower(typ): name,
+		"exit_status":        exitStatus,
+		"plugin_output":      output,
+		"performance_data":   perfData,
+		"check_source":       "kubernetes-icinga",
+	}
+	if ttl > 0 {
+		body["ttl"] = ttl
+	}
+
+	return a.request("POST", "/actions/process-check-result", body, nil)
+}
+
+func (a *IcingaWebAPI) queryStates(objects, typ, tag string) ([]IcingaState, error) {
+	var response struct {
+		Results []struct {
+			Name  string `json:"name"`
+			Attrs struct {
+				State           float64 `json:"state"`
+				StateType       float64 `json:"state_type"`
+				LastStateChange float64 `json:"last_state_change"`
+				LastCheckResult *struct {
+					Output string `json:"output"`
+				} `json:"last_check_result"`
+				Vars map[string]interface{} `json:"vars"`
+			} `json:"attrs"`
+		} `json:"results"`
+	}
+
+	err := a.request("GET", "/objects/"+objects, map[string]interface{}{
+		"filter":      fmt.Sprintf("%s.vars.%s == tag", typ, VarCluster),
+		"filter_vars": map[string]string{"tag": tag},
+		"attrs":       []string{"state", "state_type", "last_state_change", "last_check_result", "vars"},
+	}, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	states := make([]IcingaState, 0, len(response.Results))
+	for _, r := range response.Results {
+		s := IcingaState{
+			Name:            r.Name,
+			State:           int(r.Attrs.State),
+			StateType:       int(r.Attrs.StateType),
+			LastStateChange: unixTime(r.Attrs.LastStateChange),
+		}
+		if owner, ok := r.Attrs.Vars[VarOwner].(string); ok {
+			s.Owner = owner
+		}
+		if r.Attrs.LastCheckResult != nil {
+			s.Checked = true
+			s.Output = r.Attrs.LastCheckResult.Output
+		}
+		states = append(states, s)
+	}
+
+	return states, nil
+}
+
+// Send a request to the Icinga API. Everything is sent as POST, with the real method in the override header.
+func (a *IcingaWebAPI) request(method, path string, body interface{}, result interface{}) error {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest("POST", a.URL+"/v1"+path, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.SetBasicAuth(a.Username, a.Password)
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Content-Type", "application/json")
+	if method != "POST" {
+		req.Header.Set("X-HTTP-Method-Override", method)
+	}
+
+	if a.Debug {
+		log.Debugf("icinga api request: %s %s %s", method, path, string(data))
+	}
+
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	data, err = ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if a.Debug {
+		log.Debugf("icinga api response: %s %s", resp.Status, string(data))
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return &IcingaAPIError{Method: method, Path: path, Status: resp.Status, StatusCode: resp.StatusCode, Body: string(data)}
+	}
+
+	if result != nil {
+		return json.Unmarshal(data, result)
+	}
+
+	return nil
+}
+
+// An error response from the Icinga API.
+type IcingaAPIError struct {
+	Method     string
+	Path       string
+	Status     string
+	StatusCode int
+	Body       string
+}
+
+func (e *IcingaAPIError) Error() string {
+	return fmt.Sprintf("icinga api %s %s returned %s: %s", e.Method, e.Path, e.Status, e.Body)
+}
+
+// True if the Icinga API did not find the object a request was about.
+func IsIcingaNotFound(err error) bool {
+	e, ok := err.(*IcingaAPIError)
+	return ok && e.StatusCode == http.StatusNotFound
+}
+
+// Icinga timestamps are float seconds. We only keep full seconds, as that is all that survives a roundtrip
+// through the Kubernetes API.
+func unixTime(t float64) time.Time {
+	if t <= 0 {
+		return time.Time{}
+	}
+	return time.Unix(int64(t), 0)
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+)
+
+// The informers that are not part of the generated controller have their own factories and queues. This is the
+// plumbing they share.
+
+// An informer factory, typed or dynamic.
+type informerFactory interface {
+	Start(stopCh <-chan struct{})
+}
+
+// Queue the namespace/name keys of the objects an informer adds or updates. deleted is called with the last known
+// state of deleted objects.
+func enqueue(informer cache.SharedIndexInformer, queue workqueue.RateLimitingInterface, deleted func(obj interface{}) error) {
+	informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+
+		AddFunc: func(obj interface{}) {
+			if key, err := cache.MetaNamespaceKeyFunc(obj); err == nil {
+				queue.Add(key)
+			}
+		},
+
+		UpdateFunc: func(old, new interface{}) {
+			if key, err := cache.MetaNamespaceKeyFunc(new); err == nil {
+				queue.Add(key)
+			}
+		},
+
+		DeleteFunc: func(obj interface{}) {
+			if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+				obj = tombstone.Obj
+			}
+
+			if err := deleted(obj); err != nil {
+				log.Errorf("failed to process deletion: %s", err.Error())
+			}
+		},
+	})
+}
+
+// Start a factory, wait for an informer to sync and process the keys in queue until stopCh is closed.
+func runQueue(factory informerFactory, synced cache.InformerSynced, queue workqueue.RateLimitingInterface, process func(key string) error, stopCh <-chan struct{}) {
+	defer runtime.HandleCrash()
+	defer queue.ShutDown()
+
+	factory.Start(stopCh)
+
+	if !cache.WaitForCacheSync(stopCh, synced) {
+		runtime.HandleError(fmt.Errorf("Timed out waiting for caches to sync"))
+		return
+	}
+
+	go wait.Until(func() {
+		for processNext(queue, process) {
+		}
+	}, time.Second, stopCh)
+
+	<-stopCh
+}
+
+// Process the next key in a queue. Returns false if the queue was shut down.
+func processNext(queue workqueue.RateLimitingInterface, process func(key string) error) bool {
+	obj, shutdown := queue.Get()
+	if shutdown {
+		return false
+	}
+
+	err := func(obj interface{}) error {
+		defer queue.Done(obj)
+		var key string
+		var ok bool
+
+		if key, ok = obj.(string); !ok {
+			queue.Forget(obj)
+			runtime.HandleError(fmt.Errorf("expected string in workqueue but got %#v", obj))
+			return nil
+		}
+
+		if err := process(key); err != nil {
+			return fmt.Errorf("error syncing '%s': %s", key, err.Error())
+		}
+
+		queue.Forget(obj)
+		return nil
+	}(obj)
+
+	if err != nil {
+		runtime.HandleError(err)
+	}
+
+	return true
+}
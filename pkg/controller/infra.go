@@ -1,13 +1,119 @@
 package main
 
 import (
+	"fmt"
+	"strings"
+	"time"
+
 	log "github.com/sirupsen/logrus"
 
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 
-	"time"
+	"github.com/Nexinto/kubernetes-icinga/pkg/health"
 )
 
+// Check the API server health endpoints /livez and /readyz. Each endpoint gets a check with a summary and every
+// individual check of an endpoint a check of its own, named after the endpoint and the check. The results are
+// always sent as passive check results.
+func (c *Controller) RefreshAPIServer() {
+	for {
+		c.refreshAPIServer()
+		time.Sleep(60 * time.Second)
+	}
+}
+
+func (c *Controller) refreshAPIServer() {
+	if err := c.Mapping.MonitorAPIServer(c); err != nil {
+		log.Errorf("error creating host for the api server: %s", err.Error())
+		return
+	}
+
+	checks := make(map[string]health.Result)
+
+	for _, endpoint := range []string{"livez", "readyz"} {
+		// The output lists the individual checks, also if the endpoint reports an error.
+		output, err := c.Kubernetes.Discovery().RESTClient().Get().AbsPath("/"+endpoint).Param("verbose", "true").DoRaw()
+
+		results := health.Healthz(endpoint, string(output))
+		if len(results) == 0 {
+			r := health.Result{State: health.Unknown, Output: fmt.Sprintf("%s reported no checks", endpoint)}
+			if err != nil {
+				r.Output = fmt.Sprintf("error getting /%s: %s", endpoint, err.Error())
+			}
+			checks[endpoint] = r
+			continue
+		}
+
+		summary := health.Result{State: health.OK}
+		var failed []string
+		for _, check := range results {
+			checks[endpoint+"-"+strings.Replace(check.Name, "/", "-", -1)] = check.Result
+			if check.Result.State != health.OK {
+				summary.State = health.Worst(summary.State, check.Result.State)
+				failed = append(failed, check.Name)
+			}
+		}
+
+		summary.Output = fmt.Sprintf("%s: %d of %d checks passed", endpoint, len(results)-len(failed), len(results))
+		if len(failed) > 0 {
+			summary.Output += ", failed: " + strings.Join(failed, ", ")
+		}
+		checks[endpoint] = summary
+	}
+
+	vars := c.MakeVars(&metav1.ObjectMeta{Name: "apiserver"}, "apiserver", false)
+	names := make(map[string]bool)
+
+	for label, r := range checks {
+		meta := metav1.ObjectMeta{Name: "apiserver-" + label, Namespace: "kube-system"}
+		names[meta.Name] = true
+		host, name := c.Mapping.APIServerCheck(c, label)
+
+		if err := c.passiveCheck(meta, host, name, vars, r); err != nil {
+			log.Errorf("error creating api server check '%s': %s", label, err.Error())
+		}
+	}
+
+	// Remove the checks the API server no longer reports.
+	existing, err := c.CheckLister.Checks("kube-system").List(labels.Everything())
+	if err != nil {
+		log.Errorf("error listing checks: %s", err.Error())
+		return
+	}
+
+	for _, check := range existing {
+		if strings.HasPrefix(check.Name, "apiserver-") && !names[check.Name] {
+			if err := c.deleteCheck("kube-system", check.Name); err != nil {
+				log.Errorf("error deleting api server check '%s': %s", check.Name, err.Error())
+			}
+		}
+	}
+}
+
+// Control plane pods are the static pods of the control plane in kube-system, as created by kubeadm. They are owned
+// by their node and monitored as infrastructure.
+func (c *Controller) processControlPlanePod(pod *corev1.Pod) error {
+	log.Debugf("processing control plane pod '%s'", pod.Name)
+	if pod.GetDeletionTimestamp() != nil {
+		return c.Mapping.UnmonitorControlPlanePod(c, pod)
+	}
+
+	if err := c.Mapping.MonitorControlPlanePod(c, pod); err != nil {
+		return err
+	}
+	typ, name := c.Mapping.ControlPlanePodCheckable(c, pod)
+	c.submitCheckResult(typ, name, pod)
+	return nil
+}
+
+func controlPlanePod(pod *corev1.Pod) bool {
+	_, mirror := pod.GetAnnotations()["kubernetes.io/config.mirror"]
+	return pod.Namespace == "kube-system" && mirror && pod.Labels["tier"] == "control-plane"
+}
+
+// Only used with COMPONENT_STATUSES set, for clusters without health endpoints.
 func (c *Controller) RefreshComponentStatutes() {
 	for {
 		// Have to do it manually as they cannot be watched.
@@ -21,7 +127,10 @@ func (c *Controller) RefreshComponentStatutes() {
 				err := c.Mapping.MonitorComponentStatus(c, &cs)
 				if err != nil {
 					log.Errorf("error creating check for componentstatus '%s': %s", cs.Name, err.Error())
+					continue
 				}
+				typ, name := c.Mapping.ComponentStatusCheckable(c, &cs)
+				c.submitCheckResult(typ, name, &cs)
 			}
 		}
 
@@ -0,0 +1,238 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+
+	networkingv1beta1 "k8s.io/api/networking/v1beta1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+
+	icingav1 "github.com/Nexinto/kubernetes-icinga/pkg/apis/icinga.nexinto.com/v1"
+)
+
+// Days a certificate has to be valid before http checks warn and become critical.
+const httpCheckCertificateDays = "30,14"
+
+var nonNameChars = regexp.MustCompile("[^a-z0-9]+")
+
+// Set up an informer for Ingresses, if the cluster serves networking.k8s.io/v1beta1. Kubernetes 1.22 removed it.
+func (c *Controller) InitializeIngresses() {
+	if !servesResource(c.Kubernetes.Discovery(), "networking.k8s.io/v1beta1", "ingresses") {
+		return
+	}
+
+	informer := c.KubernetesFactory.Networking().V1beta1().Ingresses()
+	c.IngressQueue = workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
+	c.IngressLister = informer.Lister()
+	c.IngressSynced = informer.Informer().HasSynced
+
+	enqueue(informer.Informer(), c.IngressQueue, func(obj interface{}) error {
+		o, ok := obj.(*networkingv1beta1.Ingress)
+		if !ok {
+			return fmt.Errorf("object is not an Ingress %+v", obj)
+		}
+		return c.IngressDeleted(o)
+	})
+}
+
+func (c *Controller) RunIngresses(stopCh <-chan struct{}) {
+	if c.IngressQueue == nil {
+		return
+	}
+	runQueue(c.KubernetesFactory, c.IngressSynced, c.IngressQueue, c.processIngress, stopCh)
+}
+
+func (c *Controller) processIngress(key string) error {
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		return fmt.Errorf("could not parse name %s: %s", key, err.Error())
+	}
+
+	o, err := c.IngressLister.Ingresses(namespace).Get(name)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return fmt.Errorf("tried to get %s, but it was not found", key)
+		} else {
+			return fmt.Errorf("error getting %s from cache: %s", key, err.Error())
+		}
+	}
+
+	return c.IngressCreatedOrUpdated(o)
+}
+
+func (c *Controller) IngressCreatedOrUpdated(ingress *networkingv1beta1.Ingress) error {
+	if err := c.processWorkload(ingress, "ing", "ingress", "Ingress", "networking.k8s.io/v1beta1"); err != nil {
+		return err
+	}
+
+	var checks []*icingav1.Check
+	// The http checks are attached to the ingress, which is only monitored if we can check it.
+	if c.monitored(ingress) && c.checkable("ingress") && ingress.GetDeletionTimestamp() == nil {
+		if a, ok := ingress.GetAnnotations()[AnnHTTPChecks]; ok && a != "" {
+			checks = c.makeHTTPChecks(ingress)
+		}
+	}
+
+	return c.reconcileHTTPChecks(ingress, checks)
+}
+
+func (c *Controller) IngressDeleted(ingress *networkingv1beta1.Ingress) error {
+	log.Debugf("processing deleted ingress '%s/%s'", ingress.Namespace, ingress.Name)
+	if err := c.reconcileHTTPChecks(ingress, nil); err != nil {
+		return err
+	}
+	return c.Mapping.UnmonitorWorkload(c, ingress, "ing")
+}
+
+// An http check for each host and path of the ingress and a certificate check for each TLS host. Wildcard hosts
+// cannot be checked.
+func (c *Controller) makeHTTPChecks(ingress *networkingv1beta1.Ingress) []*icingav1.Check {
+	var checks []*icingav1.Check
+
+	// check_http only checks the certificate if it is asked to, so that is a check of its own.
+	tls := make(map[string]bool)
+	for _, t := range ingress.Spec.TLS {
+		for _, h := range t.Hosts {
+			if h == "" || strings.Contains(h, "*") || tls[h] {
+				continue
+			}
+			tls[h] = true
+
+			checkName := strings.ToLower(h)
+			host, name := c.Mapping.AttachedCheck(c, ingress, "ing", "certificate-"+checkName)
+
+			meta := MakeObjectMeta(ingress, "Ingress", "networking.k8s.io/v1beta1", "", false)
+			meta.Name = certificateCheckPrefix(ingress) + checkName
+
+			checks = append(checks, &icingav1.Check{
+				ObjectMeta: meta,
+				Spec: icingav1.CheckSpec{
+					Name:         name,
+					Host:         host,
+					CheckCommand: "http",
+					Vars: map[string]string{
+						"http_address":     h,
+						"http_vhost":       h,
+						"http_ssl":         "true",
+						"http_sni":         "true",
+						"http_certificate": httpCheckCertificateDays,
+						VarType:            "ingress",
+						VarName:            ingress.Name,
+						VarNamespace:       ingress.Namespace,
+					},
+				},
+			})
+		}
+	}
+
+	for _, rule := range ingress.Spec.Rules {
+		if rule.Host == "" || strings.Contains(rule.Host, "*") {
+			continue
+		}
+
+		paths := []string{"/"}
+		if rule.HTTP != nil && len(rule.HTTP.Paths) > 0 {
+			paths = nil
+			for _, p := range rule.HTTP.Paths {
+				if p.Path == "" {
+					paths = append(paths, "/")
+				} else {
+					paths = append(paths, p.Path)
+				}
+			}
+		}
+
+		for _, path := range paths {
+			checkName := httpCheckName(rule.Host, path)
+			host, name := c.Mapping.AttachedCheck(c, ingress, "ing", "http-"+checkName)
+
+			vars := map[string]string{
+				"http_address": rule.Host,
+				"http_vhost":   rule.Host,
+				"http_uri":     path,
+				VarType:        "ingress",
+				VarName:        ingress.Name,
+				VarNamespace:   ingress.Namespace,
+			}
+			if tls[rule.Host] {
+				vars["http_ssl"] = "true"
+				vars["http_sni"] = "true"
+			}
+
+			meta := MakeObjectMeta(ingress, "Ingress", "networking.k8s.io/v1beta1", "", false)
+			meta.Name = httpCheckPrefix(ingress) + checkName
+
+			checks = append(checks, &icingav1.Check{
+				ObjectMeta: meta,
+				Spec: icingav1.CheckSpec{
+					Name:         name,
+					Host:         host,
+					CheckCommand: "http",
+					Vars:         vars,
+				},
+			})
+		}
+	}
+
+	return checks
+}
+
+// Create or update the http checks of an ingress and delete the ones no longer needed.
+func (c *Controller) reconcileHTTPChecks(ingress *networkingv1beta1.Ingress, checks []*icingav1.Check) error {
+	keep := make(map[string]bool)
+
+	for _, check := range checks {
+		if keep[check.Name] {
+			continue
+		}
+		keep[check.Name] = true
+		if err := c.reconcileCheck(check); err != nil {
+			return err
+		}
+	}
+
+	existing, err := c.CheckLister.Checks(ingress.Namespace).List(labels.Everything())
+	if err != nil {
+		return fmt.Errorf("error listing checks: %s", err.Error())
+	}
+
+	for _, check := range existing {
+		// Another ingress may have a name that starts with our prefix.
+		if !isHTTPCheck(ingress, check) || !ownedBy(check, ingress) || keep[check.Name] {
+			continue
+		}
+		if err := c.deleteCheck(check.Namespace, check.Name); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func httpCheckPrefix(ingress *networkingv1beta1.Ingress) string {
+	return "ing-" + ingress.Name + "-http-"
+}
+
+func certificateCheckPrefix(ingress *networkingv1beta1.Ingress) string {
+	return "ing-" + ingress.Name + "-certificate-"
+}
+
+// Is check one of the http or certificate checks of an ingress?
+func isHTTPCheck(ingress *networkingv1beta1.Ingress, check *icingav1.Check) bool {
+	return strings.HasPrefix(check.Name, httpCheckPrefix(ingress)) || strings.HasPrefix(check.Name, certificateCheckPrefix(ingress))
+}
+
+// Name an http check after its host and path, for example www.example.com-api.
+func httpCheckName(host, path string) string {
+	name := strings.ToLower(host)
+	if p := strings.Trim(nonNameChars.ReplaceAllString(strings.ToLower(path), "-"), "-"); p != "" {
+		name += "-" + p
+	}
+	return name
+}
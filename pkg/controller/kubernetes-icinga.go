@@ -3,10 +3,15 @@ package main
 import (
 	"flag"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 
 	log "github.com/sirupsen/logrus"
 
 	"github.com/Nexinto/go-icinga2-client/icinga2"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/tools/clientcmd"
 
@@ -46,11 +51,20 @@ func main() {
 		panic(err.Error())
 	}
 
+	if err := CheckAPIResources(kubernetesclient.Discovery(), os.Getenv("COMPONENT_STATUSES") == "true"); err != nil {
+		panic(err.Error())
+	}
+
 	icingaclient, err := icingaclientset.NewForConfig(clientConfig)
 	if err != nil {
 		panic(err.Error())
 	}
 
+	dynamicclient, err := dynamic.NewForConfig(clientConfig)
+	if err != nil {
+		panic(err.Error())
+	}
+
 	var tag string
 
 	if e := os.Getenv("TAG"); e != "" {
@@ -79,12 +93,117 @@ func main() {
 		}
 	}
 
+	checkMode := CheckModeActive
+
+	switch e := os.Getenv("CHECK_MODE"); e {
+	case "", CheckModeActive:
+	case CheckModePassive:
+		checkMode = CheckModePassive
+	case CheckModeHTTP:
+		checkMode = CheckModeHTTP
+	default:
+		panic("unknown CHECK_MODE " + e)
+	}
+
+	var eventIgnoredReasons []string
+
+	if e := os.Getenv("EVENT_IGNORED_REASONS"); e != "" {
+		for _, reason := range strings.Split(e, ",") {
+			eventIgnoredReasons = append(eventIgnoredReasons, strings.TrimSpace(reason))
+		}
+	}
+
+	var monitoredResources []*MonitoredResource
+
+	if e := os.Getenv("MONITORED_RESOURCES"); e != "" {
+		monitoredResources, err = ParseMonitoredResources(e)
+		if err != nil {
+			panic("error parsing MONITORED_RESOURCES: " + err.Error())
+		}
+	}
+
+	httpCheckCommand := "kubernetes_http"
+
+	if e := os.Getenv("HTTP_CHECK_COMMAND"); e != "" {
+		httpCheckCommand = e
+	}
+
+	checkListen := ":8080"
+
+	if e := os.Getenv("CHECK_LISTEN"); e != "" {
+		checkListen = e
+	}
+
+	checkURL := os.Getenv("CHECK_URL")
+	checkToken := os.Getenv("CHECK_TOKEN")
+
+	if checkMode == CheckModeHTTP {
+		if checkURL == "" {
+			panic("CHECK_URL is required with CHECK_MODE http")
+		}
+		if checkToken == "" {
+			panic("CHECK_TOKEN is required with CHECK_MODE http")
+		}
+	}
+
 	c := &Controller{
 		Kubernetes:   kubernetesclient,
 		IcingaClient: icingaclient,
 		Icinga:       icingaApi,
-		Tag:          tag,
-		DefaultVars:  defaultVars,
+		IcingaAPI: NewIcingaWebAPI(
+			os.Getenv("ICINGA_URL"),
+			os.Getenv("ICINGA_USER"),
+			os.Getenv("ICINGA_PASSWORD"),
+			os.Getenv("ICINGA_DEBUG") == "true",
+			true),
+		Tag:         tag,
+		DefaultVars: defaultVars,
+		CheckMode:   checkMode,
+		PassiveTTL:  envInt("PASSIVE_TTL", 300),
+
+		LoadBalancerGrace: envSeconds("LOADBALANCER_GRACE", 600),
+		JobRetention:      envSeconds("JOB_RETENTION", 3600),
+		CronJobWindowRuns: envInt("CRONJOB_WINDOW_RUNS", 2),
+		PVCPendingGrace:   envSeconds("PVC_PENDING_GRACE", 300),
+
+		HPAMaxReplicasGrace: envSeconds("HPA_MAX_REPLICAS_GRACE", 1800),
+		PDBBlockedGrace:     envSeconds("PDB_BLOCKED_GRACE", 3600),
+
+		ComponentStatuses:  os.Getenv("COMPONENT_STATUSES") == "true",
+		StuckDeletionGrace: envSeconds("STUCK_DELETION_GRACE", 600),
+
+		RestartWindow:   envSeconds("RESTART_WINDOW", 3600),
+		RestartWarning:  envInt("RESTART_WARNING", 3),
+		PodPendingGrace: envSeconds("POD_PENDING_GRACE", 600),
+
+		RolloutChecks: os.Getenv("ROLLOUT_CHECKS") == "true",
+		RolloutGrace:  envSeconds("ROLLOUT_GRACE", 900),
+
+		NodeConditionChecks: os.Getenv("NODE_CONDITION_CHECKS") == "true",
+		NodeLeaseGrace:      envSeconds("NODE_LEASE_GRACE", 40),
+
+		EventWindow:         envSeconds("EVENT_WINDOW", 900),
+		EventWarning:        envInt("EVENT_WARNING", 10),
+		EventCritical:       envInt("EVENT_CRITICAL", 50),
+		EventIgnoredReasons: eventIgnoredReasons,
+
+		UsageWarning:  envInt("USAGE_WARNING", 80),
+		UsageCritical: envInt("USAGE_CRITICAL", 90),
+
+		QuotaWarning:  envInt("QUOTA_WARNING", 80),
+		QuotaCritical: envInt("QUOTA_CRITICAL", 95),
+
+		HelmPendingGrace: envSeconds("HELM_PENDING_GRACE", 600),
+
+		TLSWarningDays:  envInt("TLS_WARNING_DAYS", 30),
+		TLSCriticalDays: envInt("TLS_CRITICAL_DAYS", 14),
+
+		HTTPCheckCommand: httpCheckCommand,
+		CheckURL:         checkURL,
+		CheckToken:       checkToken,
+
+		Dynamic:            dynamicclient,
+		MonitoredResources: monitoredResources,
 	}
 
 	switch os.Getenv("MAPPING") {
@@ -97,14 +216,90 @@ func main() {
 	}
 
 	c.Initialize()
+	c.InitializeIngresses()
+	c.InitializeCronJobs()
+	c.InitializeHorizontalPodAutoscalers()
+	c.InitializePodDisruptionBudgets()
+	c.InitializeMonitoredResources()
+	c.InitializeAPIServices()
+
+	if os.Getenv("EVENT_CHECKS") == "true" {
+		c.InitializeEvents()
+	}
+
+	// Certificates and Helm releases are evaluated by us, check_kubernetes cannot check them.
+	if checkMode != CheckModeActive {
+		c.InitializeTLSSecrets()
+	}
+
+	helmChecks := checkMode != CheckModeActive && os.Getenv("HELM_CHECKS") == "true"
+
+	if helmChecks {
+		c.InitializeHelmReleases()
+	}
 
 	if err := c.Mapping.MonitorCluster(c); err != nil {
 		log.Errorf("error setting up monitoring for the cluster: %s", err.Error())
 	}
 
-	go c.RefreshComponentStatutes()
+	if c.ComponentStatuses {
+		go c.RefreshComponentStatutes()
+	} else {
+		go c.RefreshAPIServer()
+	}
 	go c.EnsureDefaultHostgroups()
+	go c.RefreshStuckDeletions()
 	go c.IcingaHousekeeping()
+	go c.RefreshIcingaStates()
+	go c.RunIngresses(wait.NeverStop)
+	go c.RunCronJobs(wait.NeverStop)
+	go c.RunHorizontalPodAutoscalers(wait.NeverStop)
+	go c.RunPodDisruptionBudgets(wait.NeverStop)
+	go c.RunMonitoredResources(wait.NeverStop)
+	go c.RunAPIServices(wait.NeverStop)
+
+	if checkMode != CheckModeActive {
+		go c.RunTLSSecrets(wait.NeverStop)
+	}
+
+	if helmChecks {
+		go c.RunHelmReleases(wait.NeverStop)
+	}
+
+	if os.Getenv("EVENT_CHECKS") == "true" {
+		go c.RunEvents(wait.NeverStop)
+	}
+
+	if os.Getenv("USAGE_CHECKS") == "true" {
+		go c.RefreshUsage()
+	}
+
+	if os.Getenv("CONTAINER_CHECKS") == "true" {
+		go c.RefreshContainers()
+	}
+
+	if checkMode == CheckModeHTTP {
+		go c.ServeChecks(checkListen)
+	}
 
 	c.Start()
 }
+
+// Get an integer from an environment variable, def if it is not set.
+func envInt(name string, def int) int {
+	e := os.Getenv(name)
+	if e == "" {
+		return def
+	}
+
+	i, err := strconv.Atoi(e)
+	if err != nil {
+		panic("error parsing " + name + ": " + err.Error())
+	}
+	return i
+}
+
+// Get a duration in seconds from an environment variable, def seconds if it is not set.
+func envSeconds(name string, def int) time.Duration {
+	return time.Duration(envInt(name, def)) * time.Second
+}
@@ -1,6 +1,20 @@
 package main
 
 import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
 	"testing"
 	"time"
 
@@ -11,15 +25,29 @@ import (
 
 	"github.com/Nexinto/go-icinga2-client/icinga2"
 
-	appsv1beta2 "k8s.io/api/apps/v1beta2"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2beta2 "k8s.io/api/autoscaling/v2beta2"
+	batchv1 "k8s.io/api/batch/v1"
+	batchv1beta1 "k8s.io/api/batch/v1beta1"
+	coordinationv1 "k8s.io/api/coordination/v1"
 	corev1 "k8s.io/api/core/v1"
-	extensionsv1beta1 "k8s.io/api/extensions/v1beta1"
+	networkingv1beta1 "k8s.io/api/networking/v1beta1"
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	kubernetesinformers "k8s.io/client-go/informers"
 	"k8s.io/client-go/kubernetes/fake"
+	appslisterv1 "k8s.io/client-go/listers/apps/v1"
+	k8stesting "k8s.io/client-go/testing"
 	"k8s.io/client-go/tools/cache"
 
 	icingav1 "github.com/Nexinto/kubernetes-icinga/pkg/apis/icinga.nexinto.com/v1"
 	icingafake "github.com/Nexinto/kubernetes-icinga/pkg/client/clientset/versioned/fake"
+	"github.com/Nexinto/kubernetes-icinga/pkg/health"
 )
 
 type KubernetesIcingaTestSuite struct {
@@ -67,6 +95,7 @@ func testEnvironment(mapping Mapping) *Controller {
 		Kubernetes:   fake.NewSimpleClientset(),
 		IcingaClient: icingafake.NewSimpleClientset(),
 		Icinga:       icinga2.NewMockClient(),
+		IcingaAPI:    &mockIcingaAPI{},
 		Tag:          "testing",
 		Mapping:      mapping,
 	}
@@ -75,16 +104,38 @@ func testEnvironment(mapping Mapping) *Controller {
 	c.Kubernetes.CoreV1().Namespaces().Create(&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "kube-system"}})
 	c.Kubernetes.CoreV1().Namespaces().Create(&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "kube-public"}})
 
+	// The resources that are only watched if the cluster serves them.
+	c.Kubernetes.Discovery().(*fakediscovery.FakeDiscovery).Resources = []*metav1.APIResourceList{
+		{GroupVersion: "networking.k8s.io/v1beta1", APIResources: []metav1.APIResource{{Name: "ingresses"}}},
+		{GroupVersion: "batch/v1beta1", APIResources: []metav1.APIResource{{Name: "cronjobs"}}},
+		{GroupVersion: "autoscaling/v2beta2", APIResources: []metav1.APIResource{{Name: "horizontalpodautoscalers"}}},
+		{GroupVersion: "policy/v1beta1", APIResources: []metav1.APIResource{{Name: "poddisruptionbudgets"}}},
+	}
+
 	c.Initialize()
+	c.InitializeIngresses()
+	c.InitializeCronJobs()
+	c.InitializeHorizontalPodAutoscalers()
+	c.InitializePodDisruptionBudgets()
+	c.InitializeTLSSecrets()
+	c.InitializeEvents()
+	c.InitializeHelmReleases()
 	go c.Start()
 
 	stopCh := make(chan struct{})
 
 	go c.Run(stopCh)
+	go c.RunIngresses(stopCh)
+	go c.RunCronJobs(stopCh)
+	go c.RunHorizontalPodAutoscalers(stopCh)
+	go c.RunPodDisruptionBudgets(stopCh)
+	go c.RunTLSSecrets(stopCh)
+	c.EventFactory.Start(stopCh)
+	go c.RunHelmReleases(stopCh)
 
 	log.Debug("waiting for cache sync")
 
-	if !cache.WaitForCacheSync(stopCh, c.PodSynced, c.NodeSynced, c.NamespaceSynced, c.DeploymentSynced, c.DaemonSetSynced, c.ReplicaSetSynced, c.StatefulSetSynced, c.HostGroupSynced, c.HostSynced, c.CheckSynced) {
+	if !cache.WaitForCacheSync(stopCh, c.PodSynced, c.NodeSynced, c.NamespaceSynced, c.ServiceSynced, c.EndpointsSynced, c.PersistentVolumeClaimSynced, c.PersistentVolumeSynced, c.ResourceQuotaSynced, c.DeploymentSynced, c.DaemonSetSynced, c.ReplicaSetSynced, c.StatefulSetSynced, c.HorizontalPodAutoscalerSynced, c.PodDisruptionBudgetSynced, c.ValidatingWebhookConfigurationSynced, c.MutatingWebhookConfigurationSynced, c.JobSynced, c.CronJobSynced, c.IngressSynced, c.SecretSynced, c.EventSynced, c.HelmSynced, c.HostGroupSynced, c.HostSynced, c.CheckSynced) {
 		panic("Timed out waiting for caches to sync")
 	}
 
@@ -94,6 +145,54 @@ func testEnvironment(mapping Mapping) *Controller {
 	return c
 }
 
+// A fake Icinga API returning whatever states the test sets.
+type mockIcingaAPI struct {
+	sync.Mutex
+	hosts    []IcingaState
+	services []IcingaState
+	results  map[string]mockCheckResult
+	missing  map[string]bool
+}
+
+type mockCheckResult struct {
+	Type       string
+	ExitStatus int
+	Output     string
+	PerfData   []string
+}
+
+func (m *mockIcingaAPI) ListHostStates(tag string) ([]IcingaState, error) {
+	m.Lock()
+	defer m.Unlock()
+	return m.hosts, nil
+}
+
+func (m *mockIcingaAPI) ListServiceStates(tag string) ([]IcingaState, error) {
+	m.Lock()
+	defer m.Unlock()
+	return m.services, nil
+}
+
+func (m *mockIcingaAPI) ProcessCheckResult(typ, name string, exitStatus int, output string, perfData []string, ttl int) error {
+	m.Lock()
+	defer m.Unlock()
+	if m.missing[name] {
+		return &IcingaAPIError{Method: "POST", Path: "/actions/process-check-result", Status: "404 Not Found", StatusCode: 404}
+	}
+	if m.results == nil {
+		m.results = make(map[string]mockCheckResult)
+	}
+	m.results[name] = mockCheckResult{Type: typ, ExitStatus: exitStatus, Output: output, PerfData: perfData}
+	return nil
+}
+
+func (m *mockIcingaAPI) result(name string) (mockCheckResult, bool) {
+	m.Lock()
+	defer m.Unlock()
+	r, ok := m.results[name]
+	return r, ok
+}
+
 // simulate the behaviour of the controllers we depend on
 func (c *Controller) simulate() error {
 
@@ -178,6 +277,21 @@ func (s *KubernetesIcingaTestSuite) TestDoNotTouchSomeoneElsesHostgroup() {
 		return
 	}
 	a.Equal("someone", hg.GetVars()[VarCluster])
+
+	if c.Mapping.Name() == "hostgroup" {
+		cr, err := c.IcingaClient.IcingaV1().HostGroups("kube-system").Get("default", metav1.GetOptions{})
+		if !a.Nil(err) {
+			return
+		}
+		a.Equal("testing.default", cr.Status.IcingaName)
+		a.NotEmpty(cr.Status.LastError)
+		if cond := getCondition(cr.Status.SyncStatus, icingav1.ConditionNotManagedByUs); a.NotNil(cond) {
+			a.Equal(corev1.ConditionTrue, cond.Status)
+		}
+		if cond := getCondition(cr.Status.SyncStatus, icingav1.ConditionSynced); a.NotNil(cond) {
+			a.Equal(corev1.ConditionFalse, cond.Status)
+		}
+	}
 }
 
 func (s *KubernetesIcingaTestSuite) TestNamespace() {
@@ -295,7 +409,7 @@ func (s *KubernetesIcingaTestSuite) TestDeployment() {
 	a := assert.New(s.T())
 	c := s.Controller
 
-	_, err := c.Kubernetes.ExtensionsV1beta1().Deployments("default").Create(&extensionsv1beta1.Deployment{
+	_, err := c.Kubernetes.AppsV1().Deployments("default").Create(&appsv1.Deployment{
 		ObjectMeta: metav1.ObjectMeta{
 			Name: "mydeploy",
 			Annotations: map[string]string{
@@ -309,7 +423,7 @@ func (s *KubernetesIcingaTestSuite) TestDeployment() {
 		return
 	}
 
-	_, err = c.Kubernetes.ExtensionsV1beta1().Deployments("default").Create(&extensionsv1beta1.Deployment{
+	_, err = c.Kubernetes.AppsV1().Deployments("default").Create(&appsv1.Deployment{
 		ObjectMeta: metav1.ObjectMeta{
 			Name: "controlled",
 			OwnerReferences: []metav1.OwnerReference{{
@@ -323,7 +437,7 @@ func (s *KubernetesIcingaTestSuite) TestDeployment() {
 		return
 	}
 
-	_, err = c.Kubernetes.ExtensionsV1beta1().Deployments("default").Create(&extensionsv1beta1.Deployment{
+	_, err = c.Kubernetes.AppsV1().Deployments("default").Create(&appsv1.Deployment{
 		ObjectMeta: metav1.ObjectMeta{
 			Name: "unmonitored",
 			Annotations: map[string]string{
@@ -362,7 +476,7 @@ func (s *KubernetesIcingaTestSuite) TestDeployment() {
 	a.Equal("default", host.GetVars()[VarNamespace])
 	a.Equal("default/deploy-mydeploy", host.GetVars()[VarOwner])
 
-	if err := c.Kubernetes.ExtensionsV1beta1().Deployments("default").Delete("mydeploy", &metav1.DeleteOptions{}); !a.Nil(err) {
+	if err := c.Kubernetes.AppsV1().Deployments("default").Delete("mydeploy", &metav1.DeleteOptions{}); !a.Nil(err) {
 		return
 	}
 
@@ -379,7 +493,7 @@ func (s *KubernetesIcingaTestSuite) TestDaemonSet() {
 	a := assert.New(s.T())
 	c := s.Controller
 
-	_, err := c.Kubernetes.ExtensionsV1beta1().DaemonSets("default").Create(&extensionsv1beta1.DaemonSet{
+	_, err := c.Kubernetes.AppsV1().DaemonSets("default").Create(&appsv1.DaemonSet{
 		ObjectMeta: metav1.ObjectMeta{
 			Name: "myds",
 		},
@@ -406,7 +520,7 @@ func (s *KubernetesIcingaTestSuite) TestDaemonSet() {
 	a.Equal("default", host.GetVars()[VarNamespace])
 	a.Equal("default/ds-myds", host.GetVars()[VarOwner])
 
-	if err := c.Kubernetes.ExtensionsV1beta1().DaemonSets("default").Delete("myds", &metav1.DeleteOptions{}); !a.Nil(err) {
+	if err := c.Kubernetes.AppsV1().DaemonSets("default").Delete("myds", &metav1.DeleteOptions{}); !a.Nil(err) {
 		return
 	}
 
@@ -423,7 +537,7 @@ func (s *KubernetesIcingaTestSuite) TestStatefulSet() {
 	a := assert.New(s.T())
 	c := s.Controller
 
-	_, err := c.Kubernetes.AppsV1beta2().StatefulSets("default").Create(&appsv1beta2.StatefulSet{
+	_, err := c.Kubernetes.AppsV1().StatefulSets("default").Create(&appsv1.StatefulSet{
 		ObjectMeta: metav1.ObjectMeta{
 			Name: "mystate",
 		},
@@ -450,7 +564,7 @@ func (s *KubernetesIcingaTestSuite) TestStatefulSet() {
 	a.Equal("default", host.GetVars()[VarNamespace])
 	a.Equal("default/statefulset-mystate", host.GetVars()[VarOwner])
 
-	if err := c.Kubernetes.AppsV1beta2().StatefulSets("default").Delete("mystate", &metav1.DeleteOptions{}); !a.Nil(err) {
+	if err := c.Kubernetes.AppsV1().StatefulSets("default").Delete("mystate", &metav1.DeleteOptions{}); !a.Nil(err) {
 		return
 	}
 
@@ -467,7 +581,7 @@ func (s *KubernetesIcingaTestSuite) TestReplicaSet() {
 	a := assert.New(s.T())
 	c := s.Controller
 
-	_, err := c.Kubernetes.ExtensionsV1beta1().ReplicaSets("default").Create(&extensionsv1beta1.ReplicaSet{
+	_, err := c.Kubernetes.AppsV1().ReplicaSets("default").Create(&appsv1.ReplicaSet{
 		ObjectMeta: metav1.ObjectMeta{
 			Name: "myreplica",
 			Annotations: map[string]string{
@@ -480,7 +594,7 @@ func (s *KubernetesIcingaTestSuite) TestReplicaSet() {
 		return
 	}
 
-	_, err = c.Kubernetes.ExtensionsV1beta1().ReplicaSets("default").Create(&extensionsv1beta1.ReplicaSet{
+	_, err = c.Kubernetes.AppsV1().ReplicaSets("default").Create(&appsv1.ReplicaSet{
 		ObjectMeta: metav1.ObjectMeta{
 			Name: "controlled",
 			OwnerReferences: []metav1.OwnerReference{{
@@ -512,7 +626,7 @@ func (s *KubernetesIcingaTestSuite) TestReplicaSet() {
 	a.Equal("default", host.GetVars()[VarNamespace])
 	a.Equal("default/rs-myreplica", host.GetVars()[VarOwner])
 
-	if err := c.Kubernetes.ExtensionsV1beta1().ReplicaSets("default").Delete("myreplica", &metav1.DeleteOptions{}); !a.Nil(err) {
+	if err := c.Kubernetes.AppsV1().ReplicaSets("default").Delete("myreplica", &metav1.DeleteOptions{}); !a.Nil(err) {
 		return
 	}
 
@@ -525,11 +639,323 @@ func (s *KubernetesIcingaTestSuite) TestReplicaSet() {
 	}
 }
 
+func (s *KubernetesIcingaTestSuite) TestReplicaChecks() {
+	a := assert.New(s.T())
+	c := s.Controller
+
+	replicas := int32(2)
+	truth := true
+
+	ss := &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "web",
+			Namespace:   "default",
+			UID:         "ss-uid",
+			Annotations: map[string]string{AnnReplicaChecks: "true"},
+		},
+		Spec: appsv1.StatefulSetSpec{Replicas: &replicas},
+	}
+
+	ds := &appsv1.DaemonSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "fluentd",
+			Namespace:   "default",
+			UID:         "ds-uid",
+			Annotations: map[string]string{AnnReplicaChecks: "true"},
+		},
+		Spec: appsv1.DaemonSetSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "fluentd"}},
+		},
+	}
+
+	running := corev1.PodStatus{
+		Phase:             corev1.PodRunning,
+		ContainerStatuses: []corev1.ContainerStatus{{Name: "main", Ready: true}},
+	}
+
+	nodes := []*corev1.Node{
+		{ObjectMeta: metav1.ObjectMeta{Name: "node1"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "node2"}},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "node3"},
+			Spec:       corev1.NodeSpec{Taints: []corev1.Taint{{Key: "dedicated", Value: "db", Effect: corev1.TaintEffectNoSchedule}}},
+		},
+	}
+
+	for _, node := range nodes {
+		if _, err := c.Kubernetes.CoreV1().Nodes().Create(node); !a.Nil(err) {
+			return
+		}
+	}
+
+	pods := []*corev1.Pod{
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            "web-0",
+				OwnerReferences: []metav1.OwnerReference{{Kind: "StatefulSet", Name: "web", UID: ss.UID, Controller: &truth}},
+			},
+			Status: running,
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            "fluentd-abcde",
+				Labels:          map[string]string{"app": "fluentd"},
+				OwnerReferences: []metav1.OwnerReference{{Kind: "DaemonSet", Name: "fluentd", UID: ds.UID, Controller: &truth}},
+			},
+			Spec:   corev1.PodSpec{NodeName: "node1"},
+			Status: running,
+		},
+	}
+
+	for _, pod := range pods {
+		if _, err := c.Kubernetes.CoreV1().Pods("default").Create(pod); !a.Nil(err) {
+			return
+		}
+	}
+
+	if err := c.simulate(); !a.Nil(err) {
+		return
+	}
+
+	if _, err := c.Kubernetes.AppsV1().StatefulSets("default").Create(ss); !a.Nil(err) {
+		return
+	}
+	if _, err := c.Kubernetes.AppsV1().DaemonSets("default").Create(ds); !a.Nil(err) {
+		return
+	}
+
+	if err := c.simulate(); !a.Nil(err) {
+		return
+	}
+
+	service := func(host, name string) string {
+		return c.Tag + "." + host + "!" + name
+	}
+
+	tests := []struct {
+		service string
+		state   health.State
+		output  string
+	}{
+		{service(c.Mapping.AttachedCheck(c, ss, "statefulset", "web-0")), health.OK, "statefulset web: pod web-0 is Running, 1 of 1 containers ready"},
+		{service(c.Mapping.AttachedCheck(c, ss, "statefulset", "web-1")), health.Critical, "statefulset web: pod web-1 does not exist"},
+		{service(c.Mapping.NodeAttachedCheck(c, nodes[0], "ds-default-fluentd")), health.OK, "daemonset fluentd on node node1: pod fluentd-abcde is Running, 1 of 1 containers ready"},
+		{service(c.Mapping.NodeAttachedCheck(c, nodes[1], "ds-default-fluentd")), health.Critical, "daemonset fluentd: no pod on node node2"},
+	}
+
+	for _, test := range tests {
+		check, err := c.Icinga.GetService(test.service)
+		if !a.Nil(err, test.service) {
+			continue
+		}
+		a.Equal("passive", check.GetCheckCommand(), test.service)
+
+		if r, ok := c.IcingaAPI.(*mockIcingaAPI).result(test.service); a.True(ok, "no check result for %s", test.service) {
+			a.Equal(int(test.state), r.ExitStatus, test.service)
+			a.Equal(test.output, r.Output, test.service)
+		}
+	}
+
+	// The tainted node does not run the DaemonSet.
+	if _, err := c.Icinga.GetService(service(c.Mapping.NodeAttachedCheck(c, nodes[2], "ds-default-fluentd"))); !a.NotNil(err) {
+		return
+	}
+
+	// Without the annotation, the checks are removed.
+	ss.Annotations = nil
+	if _, err := c.Kubernetes.AppsV1().StatefulSets("default").Update(ss); !a.Nil(err) {
+		return
+	}
+
+	if err := c.simulate(); !a.Nil(err) {
+		return
+	}
+
+	if _, err := c.Icinga.GetService(tests[0].service); !a.NotNil(err) {
+		return
+	}
+}
+
+func TestDaemonSetRunsOn(t *testing.T) {
+	ds := &appsv1.DaemonSet{
+		Spec: appsv1.DaemonSetSpec{
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Affinity: &corev1.Affinity{
+						NodeAffinity: &corev1.NodeAffinity{
+							RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+								NodeSelectorTerms: []corev1.NodeSelectorTerm{
+									{MatchExpressions: []corev1.NodeSelectorRequirement{{Key: "role", Operator: corev1.NodeSelectorOpIn, Values: []string{"worker"}}}},
+									{MatchFields: []corev1.NodeSelectorRequirement{{Key: "metadata.name", Operator: corev1.NodeSelectorOpIn, Values: []string{"master1"}}}},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	node := func(name string, l map[string]string) *corev1.Node {
+		return &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: l}}
+	}
+
+	assert.True(t, daemonSetRunsOn(ds, node("worker1", map[string]string{"role": "worker"})))
+	assert.True(t, daemonSetRunsOn(ds, node("master1", map[string]string{"role": "master"})))
+	assert.False(t, daemonSetRunsOn(ds, node("master2", map[string]string{"role": "master"})))
+	assert.False(t, daemonSetRunsOn(ds, node("node1", nil)))
+
+	ds.Spec.Template.Spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms = []corev1.NodeSelectorTerm{
+		{MatchExpressions: []corev1.NodeSelectorRequirement{{Key: "gpu", Operator: corev1.NodeSelectorOpDoesNotExist}}},
+	}
+
+	assert.True(t, daemonSetRunsOn(ds, node("node1", nil)))
+	assert.False(t, daemonSetRunsOn(ds, node("gpu1", map[string]string{"gpu": "true"})))
+}
+
+func (s *KubernetesIcingaTestSuite) TestRollout() {
+	a := assert.New(s.T())
+	c := s.Controller
+
+	c.RolloutChecks = true
+	c.RolloutGrace = 15 * time.Minute
+
+	replicas := int32(2)
+	truth := true
+
+	d := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "web",
+			Namespace:   "default",
+			UID:         "deploy-uid",
+			Generation:  3,
+			Annotations: map[string]string{"deployment.kubernetes.io/revision": "3"},
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicas,
+			Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{Containers: []corev1.Container{{Image: "nginx:1.17"}}}},
+		},
+		Status: appsv1.DeploymentStatus{
+			ObservedGeneration: 3,
+			Replicas:           3,
+			UpdatedReplicas:    1,
+			AvailableReplicas:  2,
+			Conditions: []appsv1.DeploymentCondition{{
+				Type:    appsv1.DeploymentProgressing,
+				Status:  corev1.ConditionFalse,
+				Reason:  "ProgressDeadlineExceeded",
+				Message: `ReplicaSet "web-3" has timed out progressing.`,
+			}},
+		},
+	}
+
+	for revision, count := range map[string]int32{"1": 0, "2": 2, "3": 1} {
+		rs := &appsv1.ReplicaSet{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            "web-" + revision,
+				Namespace:       "default",
+				Annotations:     map[string]string{"deployment.kubernetes.io/revision": revision},
+				OwnerReferences: []metav1.OwnerReference{{Kind: "Deployment", Name: "web", UID: d.UID, Controller: &truth}},
+			},
+			Status: appsv1.ReplicaSetStatus{Replicas: count},
+		}
+		if _, err := c.Kubernetes.AppsV1().ReplicaSets("default").Create(rs); !a.Nil(err) {
+			return
+		}
+	}
+
+	ss := &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "default", Generation: 1},
+		Spec: appsv1.StatefulSetSpec{
+			Replicas: &replicas,
+			Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{Containers: []corev1.Container{{Image: "postgres:11"}}}},
+		},
+		Status: appsv1.StatefulSetStatus{
+			ObservedGeneration: 1,
+			CurrentRevision:    "db-7f9c",
+			UpdateRevision:     "db-7f9c",
+			UpdatedReplicas:    2,
+		},
+	}
+
+	if err := c.simulate(); !a.Nil(err) {
+		return
+	}
+
+	if _, err := c.Kubernetes.AppsV1().Deployments("default").Create(d); !a.Nil(err) {
+		return
+	}
+	if _, err := c.Kubernetes.AppsV1().StatefulSets("default").Create(ss); !a.Nil(err) {
+		return
+	}
+
+	if err := c.simulate(); !a.Nil(err) {
+		return
+	}
+
+	service := func(host, name string) string {
+		return c.Tag + "." + host + "!" + name
+	}
+
+	tests := []struct {
+		service string
+		state   health.State
+		output  string
+	}{
+		{service(c.Mapping.AttachedCheck(c, d, "deploy", "rollout")), health.Critical, `deployment web: rollout from revision 2 to 3 failed: ReplicaSet "web-3" has timed out progressing., 1 of 2 replicas updated, images nginx:1.17`},
+		{service(c.Mapping.AttachedCheck(c, ss, "statefulset", "rollout")), health.OK, "statefulset db: revision db-7f9c rolled out, 2 of 2 replicas updated, images postgres:11"},
+	}
+
+	for _, test := range tests {
+		check, err := c.Icinga.GetService(test.service)
+		if !a.Nil(err, test.service) {
+			continue
+		}
+		a.Equal("passive", check.GetCheckCommand(), test.service)
+
+		if r, ok := c.IcingaAPI.(*mockIcingaAPI).result(test.service); a.True(ok, "no check result for %s", test.service) {
+			a.Equal(int(test.state), r.ExitStatus, test.service)
+			a.Equal(test.output, r.Output, test.service)
+		}
+	}
+
+	// Without rollout checks, the checks are removed.
+	c.RolloutChecks = false
+	if _, err := c.Kubernetes.AppsV1().Deployments("default").Update(d); !a.Nil(err) {
+		return
+	}
+
+	if err := c.simulate(); !a.Nil(err) {
+		return
+	}
+
+	if _, err := c.Icinga.GetService(tests[0].service); !a.NotNil(err) {
+		return
+	}
+
+	// Once they are gone, the checks are not deleted again on every update.
+	icinga := c.IcingaClient.(*icingafake.Clientset)
+	icinga.ClearActions()
+	if _, err := c.Kubernetes.AppsV1().Deployments("default").Update(d); !a.Nil(err) {
+		return
+	}
+
+	if err := c.simulate(); !a.Nil(err) {
+		return
+	}
+
+	for _, action := range icinga.Actions() {
+		if del, ok := action.(k8stesting.DeleteAction); ok && del.GetResource().Resource == "checks" {
+			a.NotContains(del.GetName(), "rollout-", "rollout check deleted again")
+		}
+	}
+}
+
 func (s *KubernetesIcingaTestSuite) TestChangeNotes() {
 	a := assert.New(s.T())
 	c := s.Controller
 
-	_, err := c.Kubernetes.ExtensionsV1beta1().Deployments("default").Create(&extensionsv1beta1.Deployment{
+	_, err := c.Kubernetes.AppsV1().Deployments("default").Create(&appsv1.Deployment{
 		ObjectMeta: metav1.ObjectMeta{
 			Name: "mydeploy",
 			Annotations: map[string]string{
@@ -570,7 +996,7 @@ func (s *KubernetesIcingaTestSuite) TestChangeNotes() {
 
 	d.Annotations[AnnNotes] = "an even nicer deployment"
 	d.Annotations[AnnNotesURL] = "http://site.com/docsv2"
-	_, err = c.Kubernetes.ExtensionsV1beta1().Deployments("default").Update(d)
+	_, err = c.Kubernetes.AppsV1().Deployments("default").Update(d)
 	if !a.Nil(err) {
 		return
 	}
@@ -714,6 +1140,17 @@ func (s *KubernetesIcingaTestSuite) TestCustomCheck() {
 	a.Empty(check.GetVars()[VarNamespace])
 	a.Empty(check.GetVars()[VarName])
 
+	checkCr, err := c.IcingaClient.IcingaV1().Checks("default").Get("http-check", metav1.GetOptions{})
+	if !a.Nil(err) {
+		return
+	}
+	a.Equal("testing.myhost!http-check", checkCr.Status.IcingaName)
+	a.Empty(checkCr.Status.LastError)
+	a.NotNil(checkCr.Status.LastSyncTime)
+	if cond := getCondition(checkCr.Status.SyncStatus, icingav1.ConditionSynced); a.NotNil(cond) {
+		a.Equal(corev1.ConditionTrue, cond.Status)
+	}
+
 	// Delete everything
 
 	if err := c.IcingaClient.IcingaV1().Checks("default").Delete("http-check", &metav1.DeleteOptions{}); !a.Nil(err) {
@@ -755,7 +1192,7 @@ func (s *KubernetesIcingaTestSuite) TestCRHousekeeping() {
 
 	log.SetLevel(log.DebugLevel)
 
-	if _, err := c.Kubernetes.ExtensionsV1beta1().Deployments("default").Create(&extensionsv1beta1.Deployment{
+	if _, err := c.Kubernetes.AppsV1().Deployments("default").Create(&appsv1.Deployment{
 		ObjectMeta: metav1.ObjectMeta{Name: "mydeploy"}}); !a.Nil(err) {
 		return
 	}
@@ -819,3 +1256,2128 @@ func (s *KubernetesIcingaTestSuite) TestCRHousekeeping() {
 	}
 
 }
+
+func (s *KubernetesIcingaTestSuite) TestIcingaState() {
+	a := assert.New(s.T())
+	c := s.Controller
+
+	_, err := c.IcingaClient.IcingaV1().Checks("default").Create(&icingav1.Check{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "http-check",
+			Namespace: "default",
+		},
+		Spec: icingav1.CheckSpec{
+			Name:         "http-check",
+			Host:         "default",
+			CheckCommand: "check_http",
+		},
+	})
+	if !a.Nil(err) {
+		return
+	}
+
+	if err := c.simulate(); !a.Nil(err) {
+		return
+	}
+
+	changed := time.Now().Add(-time.Hour)
+
+	api := c.IcingaAPI.(*mockIcingaAPI)
+	api.Lock()
+	api.services = []IcingaState{
+		{
+			Name:            "testing.default!http-check",
+			Owner:           "default/http-check",
+			State:           2,
+			StateType:       1,
+			Checked:         true,
+			Output:          "HTTP CRITICAL: connection refused",
+			LastStateChange: time.Unix(changed.Unix(), 0),
+		},
+		{
+			Name:  "testing.default!someone-elses",
+			Owner: "default/does-not-exist",
+		},
+	}
+	api.Unlock()
+
+	c.refreshIcingaStates()
+
+	check, err := c.IcingaClient.IcingaV1().Checks("default").Get("http-check", metav1.GetOptions{})
+	if !a.Nil(err) {
+		return
+	}
+	if !a.NotNil(check.Status.CheckResult) {
+		return
+	}
+	a.Equal("CRITICAL", check.Status.CheckResult.State)
+	a.Equal("HARD", check.Status.CheckResult.StateType)
+	a.Equal("HTTP CRITICAL: connection refused", check.Status.CheckResult.Output)
+	if a.NotNil(check.Status.CheckResult.LastStateChange) {
+		a.Equal(changed.Unix(), check.Status.CheckResult.LastStateChange.Unix())
+	}
+
+	if err := c.simulate(); !a.Nil(err) {
+		return
+	}
+
+	// An unchanged state is not written again.
+	icinga := c.IcingaClient.(*icingafake.Clientset)
+	icinga.ClearActions()
+	c.refreshIcingaStates()
+
+	for _, action := range icinga.Actions() {
+		a.NotEqual("status", action.GetSubresource(), "unexpected status %s of %s", action.GetVerb(), action.GetResource().Resource)
+	}
+}
+
+func (s *KubernetesIcingaTestSuite) TestPassiveMode() {
+	a := assert.New(s.T())
+	c := s.Controller
+
+	c.CheckMode = CheckModePassive
+
+	var replicas int32 = 3
+
+	_, err := c.Kubernetes.AppsV1().Deployments("default").Create(&appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "mydeploy",
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicas,
+		},
+		Status: appsv1.DeploymentStatus{
+			ReadyReplicas:     1,
+			AvailableReplicas: 1,
+		},
+	})
+	if !a.Nil(err) {
+		return
+	}
+
+	if err := c.simulate(); !a.Nil(err) {
+		return
+	}
+
+	host, err := s.GetCheckable(s, "testing.default", "deploy-mydeploy")
+	if !a.Nil(err) {
+		return
+	}
+	a.Equal("passive", host.GetCheckCommand())
+
+	typ, name := c.Mapping.WorkloadCheckable(c, &metav1.ObjectMeta{Name: "mydeploy", Namespace: "default"}, "deploy")
+	r, ok := c.IcingaAPI.(*mockIcingaAPI).result(name)
+	if !a.True(ok, "no check result for %s", name) {
+		return
+	}
+	a.Equal(typ, r.Type)
+	if typ == "Host" {
+		a.Equal(0, r.ExitStatus)
+	} else {
+		a.Equal(int(health.Warning), r.ExitStatus)
+	}
+	a.Contains(r.Output, "1 of 3 replicas available")
+	a.Contains(r.PerfData, "desired=3;;;0")
+}
+
+func (s *KubernetesIcingaTestSuite) TestActiveModeSkipsEvaluatedTypes() {
+	a := assert.New(s.T())
+	c := s.Controller
+
+	var replicas int32 = 1
+
+	_, err := c.Kubernetes.CoreV1().Services("default").Create(&corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "mysvc"},
+	})
+	if !a.Nil(err) {
+		return
+	}
+	_, err = c.Kubernetes.AppsV1().Deployments("default").Create(&appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "mydeploy"},
+		Spec:       appsv1.DeploymentSpec{Replicas: &replicas},
+	})
+	if !a.Nil(err) {
+		return
+	}
+
+	if err := c.simulate(); !a.Nil(err) {
+		return
+	}
+
+	// check_kubernetes checks deployments, but not services.
+	if deploy, err := s.GetCheckable(s, "testing.default", "deploy-mydeploy"); a.Nil(err) {
+		a.Equal("check_kubernetes", deploy.GetCheckCommand())
+	}
+	_, err = s.GetCheckable(s, "testing.default", "svc-mysvc")
+	a.NotNil(err)
+}
+
+func (s *KubernetesIcingaTestSuite) TestCheckResultRetry() {
+	a := assert.New(s.T())
+	c := s.Controller
+
+	api := c.IcingaAPI.(*mockIcingaAPI)
+	name := c.Tag + ".infrastructure!retry"
+
+	// The Icinga object does not exist yet when the first result is sent.
+	api.Lock()
+	api.missing = map[string]bool{name: true}
+	api.Unlock()
+
+	c.processCheckResult("Service", name, health.Result{State: health.Warning, Output: "first"})
+
+	_, ok := api.result(name)
+	a.False(ok)
+
+	api.Lock()
+	api.missing = nil
+	api.Unlock()
+
+	time.Sleep(CheckResultRetryDelay + time.Second)
+
+	if r, ok := api.result(name); a.True(ok, "no check result for %s", name) {
+		a.Equal(int(health.Warning), r.ExitStatus)
+		a.Equal("first", r.Output)
+	}
+
+	// A pending retry does not overwrite a newer result.
+	api.Lock()
+	api.missing = map[string]bool{name: true}
+	api.Unlock()
+
+	c.processCheckResult("Service", name, health.Result{State: health.Critical, Output: "outdated"})
+
+	api.Lock()
+	api.missing = nil
+	api.Unlock()
+
+	c.processCheckResult("Service", name, health.Result{State: health.OK, Output: "newer"})
+
+	time.Sleep(CheckResultRetryDelay + time.Second)
+
+	if r, ok := api.result(name); a.True(ok, "no check result for %s", name) {
+		a.Equal(int(health.OK), r.ExitStatus)
+		a.Equal("newer", r.Output)
+	}
+}
+
+func (s *KubernetesIcingaTestSuite) TestHTTPCheckMode() {
+	a := assert.New(s.T())
+	c := s.Controller
+
+	c.CheckMode = CheckModeHTTP
+	c.HTTPCheckCommand = "kubernetes_http"
+	c.CheckURL = "http://kubernetes-icinga.kube-system:8080/"
+	c.CheckToken = "secret"
+
+	var replicas int32 = 3
+
+	_, err := c.Kubernetes.AppsV1().Deployments("default").Create(&appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "mydeploy",
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicas,
+		},
+		Status: appsv1.DeploymentStatus{
+			ReadyReplicas:     1,
+			AvailableReplicas: 1,
+		},
+	})
+	if !a.Nil(err) {
+		return
+	}
+
+	if err := c.simulate(); !a.Nil(err) {
+		return
+	}
+
+	deploy, err := s.GetCheckable(s, "testing.default", "deploy-mydeploy")
+	if !a.Nil(err) {
+		return
+	}
+	a.Equal("kubernetes_http", deploy.GetCheckCommand())
+	a.Equal("http://kubernetes-icinga.kube-system:8080/check/deployment/default/mydeploy", deploy.GetVars()[VarCheckURL])
+
+	check := func(path, token string) (*httptest.ResponseRecorder, CheckResponse) {
+		req := httptest.NewRequest("GET", path, nil)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		rec := httptest.NewRecorder()
+		c.handleCheck(rec, req)
+
+		var response CheckResponse
+		json.Unmarshal(rec.Body.Bytes(), &response)
+		return rec, response
+	}
+
+	rec, _ := check("/check/deployment/default/mydeploy", "")
+	a.Equal(http.StatusUnauthorized, rec.Code)
+
+	rec, _ = check("/check/deployment/default/mydeploy", "wrong")
+	a.Equal(http.StatusUnauthorized, rec.Code)
+
+	rec, response := check("/check/deployment/default/mydeploy", "secret")
+	a.Equal(http.StatusOK, rec.Code)
+	a.Equal("WARNING", response.State)
+	a.Equal(int(health.Warning), response.ExitStatus)
+	a.Contains(response.Output, "1 of 3 replicas available")
+	a.Contains(response.PerfData, "desired=3;;;0")
+
+	rec, response = check("/check/deployment/default/nothere", "secret")
+	a.Equal(http.StatusNotFound, rec.Code)
+	a.Equal("UNKNOWN", response.State)
+
+	rec, _ = check("/check/something/default/mydeploy", "secret")
+	a.Equal(http.StatusBadRequest, rec.Code)
+}
+
+func (s *KubernetesIcingaTestSuite) TestOwnerReferenceMigration() {
+	a := assert.New(s.T())
+	c := s.Controller
+
+	oldOwner := []metav1.OwnerReference{{
+		APIVersion: "v1beta1",
+		Kind:       "Deployment",
+		Name:       "mydeploy",
+	}}
+
+	owners := func() []metav1.OwnerReference {
+		if c.Mapping.Name() == "hostgroup" {
+			h, err := c.IcingaClient.IcingaV1().Hosts("default").Get("deploy-mydeploy", metav1.GetOptions{})
+			if !a.Nil(err) {
+				return nil
+			}
+			return h.OwnerReferences
+		}
+		ch, err := c.IcingaClient.IcingaV1().Checks("default").Get("deploy-mydeploy", metav1.GetOptions{})
+		if !a.Nil(err) {
+			return nil
+		}
+		return ch.OwnerReferences
+	}
+
+	var err error
+	if c.Mapping.Name() == "hostgroup" {
+		_, err = c.IcingaClient.IcingaV1().Hosts("default").Create(&icingav1.Host{
+			ObjectMeta: metav1.ObjectMeta{Name: "deploy-mydeploy", Namespace: "default", OwnerReferences: oldOwner},
+		})
+	} else {
+		_, err = c.IcingaClient.IcingaV1().Checks("default").Create(&icingav1.Check{
+			ObjectMeta: metav1.ObjectMeta{Name: "deploy-mydeploy", Namespace: "default", OwnerReferences: oldOwner},
+		})
+	}
+	if !a.Nil(err) {
+		return
+	}
+
+	if _, err := c.Kubernetes.AppsV1().Deployments("default").Create(&appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "mydeploy"}}); !a.Nil(err) {
+		return
+	}
+
+	if err := c.simulate(); !a.Nil(err) {
+		return
+	}
+
+	if o := owners(); a.Len(o, 1) {
+		a.Equal("apps/v1", o[0].APIVersion)
+		a.Equal("Deployment", o[0].Kind)
+		a.Equal("mydeploy", o[0].Name)
+	}
+}
+
+func TestCheckAPIResources(t *testing.T) {
+	a := assert.New(t)
+
+	kube := fake.NewSimpleClientset()
+	d := kube.Discovery().(*fakediscovery.FakeDiscovery)
+
+	d.Resources = []*metav1.APIResourceList{
+		{
+			GroupVersion: "v1",
+			APIResources: []metav1.APIResource{{Name: "pods"}, {Name: "nodes"}, {Name: "namespaces"}, {Name: "services"}, {Name: "endpoints"}, {Name: "secrets"}, {Name: "persistentvolumeclaims"}, {Name: "persistentvolumes"}, {Name: "resourcequotas"}},
+		},
+		{
+			GroupVersion: "extensions/v1beta1",
+			APIResources: []metav1.APIResource{{Name: "deployments"}, {Name: "daemonsets"}, {Name: "replicasets"}},
+		},
+	}
+
+	err := CheckAPIResources(d, false)
+	if a.Error(err) {
+		a.Contains(err.Error(), "apps/v1/deployments")
+		a.Contains(err.Error(), "apps/v1/statefulsets")
+	}
+
+	d.Resources = append(d.Resources, &metav1.APIResourceList{
+		GroupVersion: "apps/v1",
+		APIResources: []metav1.APIResource{{Name: "deployments"}, {Name: "daemonsets"}, {Name: "replicasets"}, {Name: "statefulsets"}},
+	}, &metav1.APIResourceList{
+		GroupVersion: "networking.k8s.io/v1beta1",
+		APIResources: []metav1.APIResource{{Name: "ingresses"}},
+	}, &metav1.APIResourceList{
+		GroupVersion: "autoscaling/v2beta2",
+		APIResources: []metav1.APIResource{{Name: "horizontalpodautoscalers"}},
+	}, &metav1.APIResourceList{
+		GroupVersion: "policy/v1beta1",
+		APIResources: []metav1.APIResource{{Name: "poddisruptionbudgets"}},
+	}, &metav1.APIResourceList{
+		GroupVersion: "apiregistration.k8s.io/v1",
+		APIResources: []metav1.APIResource{{Name: "apiservices"}},
+	}, &metav1.APIResourceList{
+		GroupVersion: "admissionregistration.k8s.io/v1",
+		APIResources: []metav1.APIResource{{Name: "validatingwebhookconfigurations"}, {Name: "mutatingwebhookconfigurations"}},
+	}, &metav1.APIResourceList{
+		GroupVersion: "batch/v1",
+		APIResources: []metav1.APIResource{{Name: "jobs"}},
+	}, &metav1.APIResourceList{
+		GroupVersion: "batch/v1beta1",
+		APIResources: []metav1.APIResource{{Name: "cronjobs"}},
+	})
+
+	a.Nil(CheckAPIResources(d, false))
+
+	// ComponentStatuses are only required if they are polled.
+	err = CheckAPIResources(d, true)
+	if a.Error(err) {
+		a.Equal("the cluster does not serve v1/componentstatuses", err.Error())
+	}
+
+	d.Resources[0].APIResources = append(d.Resources[0].APIResources, metav1.APIResource{Name: "componentstatuses"})
+	a.Nil(CheckAPIResources(d, true))
+}
+
+// CronJobs, HorizontalPodAutoscalers and PodDisruptionBudgets are only watched if the cluster serves their API
+// versions.
+func TestOptionalResources(t *testing.T) {
+	a := assert.New(t)
+
+	c := &Controller{Kubernetes: fake.NewSimpleClientset()}
+	c.KubernetesFactory = kubernetesinformers.NewSharedInformerFactory(c.Kubernetes, 0)
+
+	c.InitializeCronJobs()
+	c.InitializeHorizontalPodAutoscalers()
+	c.InitializePodDisruptionBudgets()
+	a.Nil(c.CronJobQueue)
+	a.Nil(c.CronJobLister)
+	a.Nil(c.HorizontalPodAutoscalerQueue)
+	a.Nil(c.HorizontalPodAutoscalerLister)
+	a.Nil(c.PodDisruptionBudgetQueue)
+	a.Nil(c.PodDisruptionBudgetLister)
+
+	// Jobs owned by a CronJob must not requeue it.
+	c.requeueCronJob(&batchv1.Job{ObjectMeta: metav1.ObjectMeta{
+		Namespace:       "default",
+		OwnerReferences: []metav1.OwnerReference{{Kind: "CronJob", Name: "mycj"}},
+	}})
+
+	c.Kubernetes.Discovery().(*fakediscovery.FakeDiscovery).Resources = []*metav1.APIResourceList{
+		{GroupVersion: "batch/v1beta1", APIResources: []metav1.APIResource{{Name: "cronjobs"}}},
+		{GroupVersion: "autoscaling/v2beta2", APIResources: []metav1.APIResource{{Name: "horizontalpodautoscalers"}}},
+		{GroupVersion: "policy/v1beta1", APIResources: []metav1.APIResource{{Name: "poddisruptionbudgets"}}},
+	}
+
+	c.InitializeCronJobs()
+	c.InitializeHorizontalPodAutoscalers()
+	c.InitializePodDisruptionBudgets()
+	a.NotNil(c.CronJobQueue)
+	a.NotNil(c.CronJobLister)
+	a.NotNil(c.HorizontalPodAutoscalerQueue)
+	a.NotNil(c.HorizontalPodAutoscalerLister)
+	a.NotNil(c.PodDisruptionBudgetQueue)
+	a.NotNil(c.PodDisruptionBudgetLister)
+}
+
+func (s *KubernetesIcingaTestSuite) TestMonitoredResource() {
+	a := assert.New(s.T())
+	c := s.Controller
+
+	c.CheckMode = CheckModePassive
+
+	resources, err := ParseMonitoredResources(`
+- group: cert-manager.io
+  version: v1
+  resource: certificates
+  kind: Certificate
+  abbrev: cert
+  condition: Ready
+`)
+	if !a.Nil(err) {
+		return
+	}
+	c.MonitoredResources = resources
+	r := resources[0]
+
+	cert := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "cert-manager.io/v1",
+		"kind":       "Certificate",
+		"metadata": map[string]interface{}{
+			"name":      "mycert",
+			"namespace": "default",
+		},
+		"status": map[string]interface{}{
+			"conditions": []interface{}{
+				map[string]interface{}{"type": "Ready", "status": "False", "message": "Issuing certificate"},
+			},
+		},
+	}}
+
+	if err := c.MonitoredResourceCreatedOrUpdated(r, cert); !a.Nil(err) {
+		return
+	}
+
+	if err := c.simulate(); !a.Nil(err) {
+		return
+	}
+
+	checkable, err := s.GetCheckable(s, "testing.default", "cert-mycert")
+	if !a.Nil(err) {
+		return
+	}
+	a.Equal("passive", checkable.GetCheckCommand())
+	a.Equal("certificate", checkable.GetVars()[VarType])
+	a.Equal("mycert", checkable.GetVars()[VarName])
+	a.Equal("default", checkable.GetVars()[VarNamespace])
+
+	typ, name := c.Mapping.WorkloadCheckable(c, cert, "cert")
+	result, ok := c.IcingaAPI.(*mockIcingaAPI).result(name)
+	if a.True(ok, "no check result for %s", name) {
+		if typ == "Host" {
+			a.Equal(1, result.ExitStatus)
+		} else {
+			a.Equal(int(health.Critical), result.ExitStatus)
+		}
+		a.Equal("certificate mycert: Ready is False: Issuing certificate", result.Output)
+	}
+
+	if err := c.MonitoredResourceDeleted(r, cert); !a.Nil(err) {
+		return
+	}
+
+	if err := c.simulate(); !a.Nil(err) {
+		return
+	}
+
+	if _, err := s.GetCheckable(s, "testing.default", "cert-mycert"); !a.NotNil(err) {
+		return
+	}
+}
+
+func TestParseMonitoredResources(t *testing.T) {
+	a := assert.New(t)
+
+	resources, err := ParseMonitoredResources(`
+- group: argoproj.io
+  version: v1alpha1
+  resource: rollouts
+  kind: Rollout
+  path: status.phase
+  healthy: [Healthy]
+  warning: [Progressing, Paused]
+`)
+	if a.Nil(err) && a.Len(resources, 1) {
+		r := resources[0]
+		a.Equal("rollout", r.Type)
+		a.Equal("rollout", r.Abbrev)
+		a.Equal("argoproj.io/v1alpha1", r.APIVersion())
+		a.Equal([]string{"Progressing", "Paused"}, r.Warning)
+	}
+
+	_, err = ParseMonitoredResources(`
+- group: argoproj.io
+  resource: rollouts
+  kind: Rollout
+`)
+	a.Error(err, "version is required")
+
+	_, err = ParseMonitoredResources(`
+- version: v1
+  resource: things
+  kind: Thing
+  condition: Ready
+  path: status.phase
+`)
+	a.Error(err, "condition and path are exclusive")
+}
+
+func (s *KubernetesIcingaTestSuite) TestService() {
+	a := assert.New(s.T())
+	c := s.Controller
+
+	c.CheckMode = CheckModePassive
+
+	_, err := c.Kubernetes.CoreV1().Services("default").Create(&corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "mysvc",
+		},
+		Spec: corev1.ServiceSpec{
+			Selector: map[string]string{"app": "myapp"},
+		},
+	})
+	if !a.Nil(err) {
+		return
+	}
+
+	if err := c.simulate(); !a.Nil(err) {
+		return
+	}
+
+	svc, err := s.GetCheckable(s, "testing.default", "svc-mysvc")
+	if !a.Nil(err) {
+		return
+	}
+	a.Equal("service", svc.GetVars()[VarType])
+	a.Equal("mysvc", svc.GetVars()[VarName])
+
+	typ, name := c.Mapping.WorkloadCheckable(c, &metav1.ObjectMeta{Name: "mysvc", Namespace: "default"}, "svc")
+	critical, warning := int(health.Critical), int(health.Warning)
+	if typ == "Host" {
+		critical, warning = 1, 0
+	}
+
+	if r, ok := c.IcingaAPI.(*mockIcingaAPI).result(name); a.True(ok, "no check result for %s", name) {
+		a.Equal(critical, r.ExitStatus)
+		a.Contains(r.Output, "the selector matches no pods")
+	}
+
+	// New endpoints update the state of the service.
+	_, err = c.Kubernetes.CoreV1().Endpoints("default").Create(&corev1.Endpoints{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "mysvc",
+		},
+		Subsets: []corev1.EndpointSubset{{
+			Addresses:         []corev1.EndpointAddress{{IP: "10.0.0.1"}},
+			NotReadyAddresses: []corev1.EndpointAddress{{IP: "10.0.0.2"}},
+		}},
+	})
+	if !a.Nil(err) {
+		return
+	}
+
+	if err := c.simulate(); !a.Nil(err) {
+		return
+	}
+
+	if r, ok := c.IcingaAPI.(*mockIcingaAPI).result(name); a.True(ok, "no check result for %s", name) {
+		a.Equal(warning, r.ExitStatus)
+		a.Equal("service mysvc has 1 ready and 1 not ready endpoints", r.Output)
+	}
+
+	if err := c.Kubernetes.CoreV1().Services("default").Delete("mysvc", &metav1.DeleteOptions{}); !a.Nil(err) {
+		return
+	}
+
+	if err := c.simulate(); !a.Nil(err) {
+		return
+	}
+
+	if _, err := s.GetCheckable(s, "testing.default", "svc-mysvc"); !a.NotNil(err) {
+		return
+	}
+}
+
+func (s *KubernetesIcingaTestSuite) TestCronJob() {
+	a := assert.New(s.T())
+	c := s.Controller
+
+	c.CheckMode = CheckModePassive
+	c.CronJobWindowRuns = 2
+
+	lastSchedule := metav1.NewTime(time.Now().Add(-time.Minute))
+
+	cronjob, err := c.Kubernetes.BatchV1beta1().CronJobs("default").Create(&batchv1beta1.CronJob{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "backup",
+			UID:               "backup-uid",
+			CreationTimestamp: metav1.NewTime(time.Now().Add(-5 * time.Hour)),
+		},
+		Spec:   batchv1beta1.CronJobSpec{Schedule: "0 * * * *"},
+		Status: batchv1beta1.CronJobStatus{LastScheduleTime: &lastSchedule},
+	})
+	if !a.Nil(err) {
+		return
+	}
+
+	controller := true
+	completed := metav1.NewTime(time.Now().Add(-time.Hour))
+
+	_, err = c.Kubernetes.BatchV1().Jobs("default").Create(&batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "backup-1",
+			CreationTimestamp: metav1.NewTime(time.Now().Add(-61 * time.Minute)),
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion: "batch/v1beta1",
+				Kind:       "CronJob",
+				Name:       cronjob.Name,
+				UID:        cronjob.UID,
+				Controller: &controller,
+			}},
+		},
+		Status: batchv1.JobStatus{
+			Conditions:     []batchv1.JobCondition{{Type: batchv1.JobComplete, Status: corev1.ConditionTrue}},
+			CompletionTime: &completed,
+			Succeeded:      1,
+		},
+	})
+	if !a.Nil(err) {
+		return
+	}
+
+	if err := c.simulate(); !a.Nil(err) {
+		return
+	}
+
+	cj, err := s.GetCheckable(s, "testing.default", "cj-backup")
+	if !a.Nil(err) {
+		return
+	}
+	a.Equal("cronjob", cj.GetVars()[VarType])
+
+	// Jobs created by the CronJob are not monitored themselves.
+	if _, err := s.GetCheckable(s, "testing.default", "job-backup-1"); !a.NotNil(err) {
+		return
+	}
+
+	typ, name := c.Mapping.WorkloadCheckable(c, &metav1.ObjectMeta{Name: "backup", Namespace: "default"}, "cj")
+	critical := int(health.Critical)
+	if typ == "Host" {
+		critical = 1
+	}
+
+	if r, ok := c.IcingaAPI.(*mockIcingaAPI).result(name); a.True(ok, "no check result for %s", name) {
+		a.Equal(int(health.OK), r.ExitStatus)
+		a.Equal("cronjob backup: last job backup-1 complete", r.Output)
+	}
+
+	// A failed Job makes the CronJob critical.
+	_, err = c.Kubernetes.BatchV1().Jobs("default").Create(&batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "backup-2",
+			CreationTimestamp: lastSchedule,
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion: "batch/v1beta1",
+				Kind:       "CronJob",
+				Name:       cronjob.Name,
+				UID:        cronjob.UID,
+				Controller: &controller,
+			}},
+		},
+		Status: batchv1.JobStatus{
+			Conditions: []batchv1.JobCondition{{Type: batchv1.JobFailed, Status: corev1.ConditionTrue, Message: "Job has reached the specified backoff limit"}},
+			Failed:     6,
+		},
+	})
+	if !a.Nil(err) {
+		return
+	}
+
+	if err := c.simulate(); !a.Nil(err) {
+		return
+	}
+
+	if r, ok := c.IcingaAPI.(*mockIcingaAPI).result(name); a.True(ok, "no check result for %s", name) {
+		a.Equal(critical, r.ExitStatus)
+		a.Equal("cronjob backup: last job backup-2 failed: Job has reached the specified backoff limit", r.Output)
+	}
+
+	if err := c.Kubernetes.BatchV1beta1().CronJobs("default").Delete("backup", &metav1.DeleteOptions{}); !a.Nil(err) {
+		return
+	}
+
+	if err := c.simulate(); !a.Nil(err) {
+		return
+	}
+
+	if _, err := s.GetCheckable(s, "testing.default", "cj-backup"); !a.NotNil(err) {
+		return
+	}
+}
+
+func (s *KubernetesIcingaTestSuite) TestJob() {
+	a := assert.New(s.T())
+	c := s.Controller
+
+	c.CheckMode = CheckModePassive
+	c.JobRetention = time.Hour
+
+	_, err := c.Kubernetes.BatchV1().Jobs("default").Create(&batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "migrate",
+		},
+		Status: batchv1.JobStatus{
+			Active: 1,
+		},
+	})
+	if !a.Nil(err) {
+		return
+	}
+
+	if err := c.simulate(); !a.Nil(err) {
+		return
+	}
+
+	job, err := s.GetCheckable(s, "testing.default", "job-migrate")
+	if !a.Nil(err) {
+		return
+	}
+	a.Equal("job", job.GetVars()[VarType])
+
+	// Completed Jobs stay monitored until the retention has passed.
+	completed := metav1.NewTime(time.Now().Add(-time.Minute))
+	_, err = c.Kubernetes.BatchV1().Jobs("default").Update(&batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "migrate",
+		},
+		Status: batchv1.JobStatus{
+			Conditions:     []batchv1.JobCondition{{Type: batchv1.JobComplete, Status: corev1.ConditionTrue}},
+			CompletionTime: &completed,
+			Succeeded:      1,
+		},
+	})
+	if !a.Nil(err) {
+		return
+	}
+
+	if err := c.simulate(); !a.Nil(err) {
+		return
+	}
+
+	if _, err := s.GetCheckable(s, "testing.default", "job-migrate"); !a.Nil(err) {
+		return
+	}
+
+	// Completed Jobs are checked again on resync.
+	c.JobRetention = 0
+
+	if o, err := c.JobLister.Jobs("default").Get("migrate"); a.Nil(err) {
+		a.Nil(c.JobCreatedOrUpdated(o))
+	}
+
+	if _, err := s.GetCheckable(s, "testing.default", "job-migrate"); !a.NotNil(err) {
+		return
+	}
+}
+
+func (s *KubernetesIcingaTestSuite) TestPersistentVolumeClaim() {
+	a := assert.New(s.T())
+	c := s.Controller
+
+	c.CheckMode = CheckModePassive
+	c.PVCPendingGrace = 5 * time.Minute
+
+	_, err := c.Kubernetes.CoreV1().PersistentVolumeClaims("default").Create(&corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "data",
+			CreationTimestamp: metav1.NewTime(time.Now().Add(-time.Hour)),
+		},
+		Status: corev1.PersistentVolumeClaimStatus{Phase: corev1.ClaimPending},
+	})
+	if !a.Nil(err) {
+		return
+	}
+
+	if err := c.simulate(); !a.Nil(err) {
+		return
+	}
+
+	pvc, err := s.GetCheckable(s, "testing.default", "pvc-data")
+	if !a.Nil(err) {
+		return
+	}
+	a.Equal("persistentvolumeclaim", pvc.GetVars()[VarType])
+
+	typ, name := c.Mapping.WorkloadCheckable(c, &metav1.ObjectMeta{Name: "data", Namespace: "default"}, "pvc")
+	critical := int(health.Critical)
+	if typ == "Host" {
+		critical = 1
+	}
+
+	if r, ok := c.IcingaAPI.(*mockIcingaAPI).result(name); a.True(ok, "no check result for %s", name) {
+		a.Equal(critical, r.ExitStatus)
+		a.Contains(r.Output, "persistentvolumeclaim data is Pending since")
+	}
+
+	if err := c.Kubernetes.CoreV1().PersistentVolumeClaims("default").Delete("data", &metav1.DeleteOptions{}); !a.Nil(err) {
+		return
+	}
+
+	if err := c.simulate(); !a.Nil(err) {
+		return
+	}
+
+	if _, err := s.GetCheckable(s, "testing.default", "pvc-data"); !a.NotNil(err) {
+		return
+	}
+}
+
+func (s *KubernetesIcingaTestSuite) TestPersistentVolume() {
+	a := assert.New(s.T())
+	c := s.Controller
+
+	c.CheckMode = CheckModePassive
+
+	_, err := c.Kubernetes.CoreV1().PersistentVolumes().Create(&corev1.PersistentVolume{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "pv1",
+		},
+		Spec: corev1.PersistentVolumeSpec{
+			ClaimRef: &corev1.ObjectReference{Namespace: "default", Name: "data"},
+		},
+		Status: corev1.PersistentVolumeStatus{Phase: corev1.VolumeFailed, Message: "error deleting volume"},
+	})
+	if !a.Nil(err) {
+		return
+	}
+
+	if err := c.simulate(); !a.Nil(err) {
+		return
+	}
+
+	pv, err := s.GetCheckable(s, "testing.infrastructure", "pv-pv1")
+	if !a.Nil(err) {
+		return
+	}
+	a.Equal("persistentvolume", pv.GetVars()[VarType])
+	a.Equal("", pv.GetVars()[VarNamespace])
+	a.Equal("kube-system/pv-pv1", pv.GetVars()[VarOwner])
+
+	if h, ok := pv.(icinga2.Host); ok && a.Equal(1, len(h.Groups)) {
+		a.Equal("testing.infrastructure", h.Groups[0])
+	}
+
+	_, name := c.Mapping.PersistentVolumeCheckable(c, &corev1.PersistentVolume{ObjectMeta: metav1.ObjectMeta{Name: "pv1"}})
+
+	if r, ok := c.IcingaAPI.(*mockIcingaAPI).result(name); a.True(ok, "no check result for %s", name) {
+		a.NotEqual(int(health.OK), r.ExitStatus)
+		a.Equal("persistentvolume pv1 is Failed (claim default/data): error deleting volume", r.Output)
+	}
+
+	if err := c.Kubernetes.CoreV1().PersistentVolumes().Delete("pv1", &metav1.DeleteOptions{}); !a.Nil(err) {
+		return
+	}
+
+	if err := c.simulate(); !a.Nil(err) {
+		return
+	}
+
+	if _, err := s.GetCheckable(s, "testing.infrastructure", "pv-pv1"); !a.NotNil(err) {
+		return
+	}
+}
+
+func (s *KubernetesIcingaTestSuite) TestResourceQuota() {
+	a := assert.New(s.T())
+	c := s.Controller
+
+	c.CheckMode = CheckModePassive
+	c.QuotaWarning = 80
+	c.QuotaCritical = 95
+
+	_, err := c.Kubernetes.CoreV1().ResourceQuotas("default").Create(&corev1.ResourceQuota{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "compute",
+			Annotations: map[string]string{AnnUsageCritical: "90"},
+		},
+		Status: corev1.ResourceQuotaStatus{
+			Hard: corev1.ResourceList{corev1.ResourcePods: resource.MustParse("10")},
+			Used: corev1.ResourceList{corev1.ResourcePods: resource.MustParse("9")},
+		},
+	})
+	if !a.Nil(err) {
+		return
+	}
+
+	if err := c.simulate(); !a.Nil(err) {
+		return
+	}
+
+	quota, err := s.GetCheckable(s, "testing.default", "quota-compute")
+	if !a.Nil(err) {
+		return
+	}
+	a.Equal("resourcequota", quota.GetVars()[VarType])
+
+	typ, name := c.Mapping.WorkloadCheckable(c, &metav1.ObjectMeta{Name: "compute", Namespace: "default"}, "quota")
+	critical := int(health.Critical)
+	if typ == "Host" {
+		critical = 1
+	}
+
+	if r, ok := c.IcingaAPI.(*mockIcingaAPI).result(name); a.True(ok, "no check result for %s", name) {
+		a.Equal(critical, r.ExitStatus)
+		a.Equal("resourcequota compute: pods 90.0% (9 of 10)", r.Output)
+		a.Equal([]string{"pods=9;8;9;0;10"}, r.PerfData)
+	}
+
+	if err := c.Kubernetes.CoreV1().ResourceQuotas("default").Delete("compute", &metav1.DeleteOptions{}); !a.Nil(err) {
+		return
+	}
+
+	if err := c.simulate(); !a.Nil(err) {
+		return
+	}
+
+	if _, err := s.GetCheckable(s, "testing.default", "quota-compute"); !a.NotNil(err) {
+		return
+	}
+}
+
+func (s *KubernetesIcingaTestSuite) TestUsage() {
+	a := assert.New(s.T())
+	c := s.Controller
+
+	c.CheckMode = CheckModePassive
+	c.UsageWarning = 80
+	c.UsageCritical = 90
+
+	node, err := c.Kubernetes.CoreV1().Nodes().Create(&corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "node1"},
+		Status: corev1.NodeStatus{
+			Capacity: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("1000")},
+		},
+	})
+	if !a.Nil(err) {
+		return
+	}
+
+	_, err = c.Kubernetes.CoreV1().PersistentVolumeClaims("default").Create(&corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "data",
+			Annotations: map[string]string{AnnUsageWarning: "50", AnnUsageCritical: "70"},
+		},
+		Status: corev1.PersistentVolumeClaimStatus{Phase: corev1.ClaimBound},
+	})
+	if !a.Nil(err) {
+		return
+	}
+
+	if err := c.simulate(); !a.Nil(err) {
+		return
+	}
+
+	var summary statsSummary
+	err = json.Unmarshal([]byte(`{
+		"node": {
+			"memory": {"workingSetBytes": 850, "availableBytes": 150},
+			"fs": {"availableBytes": 500, "capacityBytes": 1000, "usedBytes": 450}
+		},
+		"pods": [{
+			"volume": [
+				{"name": "data", "availableBytes": 400, "capacityBytes": 1000, "pvcRef": {"name": "data", "namespace": "default"}},
+				{"name": "tmp", "availableBytes": 100, "capacityBytes": 1000}
+			]
+		}]
+	}`), &summary)
+	if !a.Nil(err) {
+		return
+	}
+
+	c.processUsage(node, &summary)
+
+	if err := c.simulate(); !a.Nil(err) {
+		return
+	}
+
+	service := func(host, name string) string {
+		return c.Tag + "." + host + "!" + name
+	}
+
+	tests := []struct {
+		service string
+		state   health.State
+		output  string
+	}{
+		{service(c.Mapping.NodeAttachedCheck(c, node, "filesystem")), health.OK, "filesystem usage 50.0% (500 of 1000)"},
+		{service(c.Mapping.NodeAttachedCheck(c, node, "memory")), health.Warning, "memory usage 85.0% (850 of 1000)"},
+		{service(c.Mapping.AttachedCheck(c, &metav1.ObjectMeta{Name: "data", Namespace: "default"}, "pvc", "usage")), health.Warning, "filesystem usage 60.0% (600 of 1000)"},
+	}
+
+	for _, test := range tests {
+		check, err := c.Icinga.GetService(test.service)
+		if !a.Nil(err, test.service) {
+			continue
+		}
+		a.Equal("passive", check.GetCheckCommand(), test.service)
+
+		if r, ok := c.IcingaAPI.(*mockIcingaAPI).result(test.service); a.True(ok, "no check result for %s", test.service) {
+			a.Equal(int(test.state), r.ExitStatus, test.service)
+			a.Equal(test.output, r.Output, test.service)
+		}
+	}
+
+	// In active mode, claims are not monitored and their usage is checked on the namespace.
+	c.CheckMode = CheckModeActive
+	c.processUsage(node, &summary)
+
+	if err := c.simulate(); !a.Nil(err) {
+		return
+	}
+
+	namespace, err := c.NamespaceLister.Get("default")
+	if !a.Nil(err) {
+		return
+	}
+
+	usage := service(c.Mapping.NamespaceCheck(c, namespace, "pvc-data-usage"))
+	if _, err := c.Icinga.GetService(usage); a.Nil(err, usage) {
+		if r, ok := c.IcingaAPI.(*mockIcingaAPI).result(usage); a.True(ok, "no check result for %s", usage) {
+			a.Equal(int(health.Warning), r.ExitStatus)
+		}
+	}
+
+	// Unmonitored nodes and claims without checks are not deleted again and again.
+	icinga := c.IcingaClient.(*icingafake.Clientset)
+	node.Annotations = map[string]string{AnnDisableMonitoring: "true"}
+	c.processUsage(node, &summary)
+
+	if err := c.simulate(); !a.Nil(err) {
+		return
+	}
+
+	icinga.ClearActions()
+	c.processUsage(node, &summary)
+
+	for _, action := range icinga.Actions() {
+		a.NotEqual("delete", action.GetVerb(), "unexpected %s of %s", action.GetVerb(), action.GetResource().Resource)
+	}
+}
+
+func TestUsageThresholds(t *testing.T) {
+	c := &Controller{}
+
+	thresholds := func(w, cr string) []int {
+		o := &metav1.ObjectMeta{Annotations: map[string]string{AnnUsageWarning: w, AnnUsageCritical: cr}}
+		warning, critical := c.usageThresholds(o, 80, 90)
+		return []int{warning, critical}
+	}
+
+	assert.Equal(t, []int{50, 70}, thresholds("50", "70"))
+	assert.Equal(t, []int{80, 90}, thresholds("-1", "101"))
+	assert.Equal(t, []int{80, 100}, thresholds("many", "100"))
+}
+
+// Filesystems that report more available bytes than their capacity are not full.
+func TestFsStatsUsed(t *testing.T) {
+	available, capacity := uint64(1200), uint64(1000)
+	assert.Equal(t, uint64(0), (&fsStats{AvailableBytes: &available, CapacityBytes: &capacity}).used())
+
+	available = 400
+	assert.Equal(t, uint64(600), (&fsStats{AvailableBytes: &available, CapacityBytes: &capacity}).used())
+}
+
+func (s *KubernetesIcingaTestSuite) TestContainers() {
+	a := assert.New(s.T())
+	c := s.Controller
+
+	c.RestartWarning = 3
+	c.RestartWindow = time.Hour
+	c.PodPendingGrace = 10 * time.Minute
+
+	controller := true
+
+	deployment, err := c.Kubernetes.AppsV1().Deployments("default").Create(&appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", UID: "deployment-web"},
+	})
+	if !a.Nil(err) {
+		return
+	}
+
+	_, err = c.Kubernetes.AppsV1().ReplicaSets("default").Create(&appsv1.ReplicaSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "web-5d8f",
+			UID:             "replicaset-web",
+			OwnerReferences: []metav1.OwnerReference{{Kind: "Deployment", Name: "web", UID: "deployment-web", Controller: &controller}},
+		},
+	})
+	if !a.Nil(err) {
+		return
+	}
+
+	_, err = c.Kubernetes.CoreV1().Pods("default").Create(&corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "web-5d8f-x7k2p",
+			UID:             "pod-web",
+			OwnerReferences: []metav1.OwnerReference{{Kind: "ReplicaSet", Name: "web-5d8f", UID: "replicaset-web", Controller: &controller}},
+		},
+		Status: corev1.PodStatus{
+			Phase: corev1.PodRunning,
+			ContainerStatuses: []corev1.ContainerStatus{{
+				Name:         "app",
+				RestartCount: 7,
+				State:        corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}},
+			}},
+		},
+	})
+	if !a.Nil(err) {
+		return
+	}
+
+	if err := c.simulate(); !a.Nil(err) {
+		return
+	}
+
+	c.refreshContainers()
+
+	if err := c.simulate(); !a.Nil(err) {
+		return
+	}
+
+	host, name := c.Mapping.AttachedCheck(c, deployment, "deploy", "containers")
+	service := c.Tag + "." + host + "!" + name
+
+	check, err := c.Icinga.GetService(service)
+	if !a.Nil(err) {
+		return
+	}
+	a.Equal("passive", check.GetCheckCommand())
+
+	if r, ok := c.IcingaAPI.(*mockIcingaAPI).result(service); a.True(ok, "no check result for %s", service) {
+		a.Equal(int(health.Critical), r.ExitStatus)
+		a.Equal("web-5d8f-x7k2p/app CrashLoopBackOff", r.Output)
+	}
+
+	// Restarts before kubernetes-icinga started watching are not counted, the ones after are.
+	if r := c.Restarts.observe("pod-web/app", 10, c.RestartWindow); !a.Equal(int32(3), r) {
+		return
+	}
+
+	if _, err := c.IcingaClient.IcingaV1().Checks("default").Get("rs-web-5d8f-containers", metav1.GetOptions{}); !a.NotNil(err) {
+		return
+	}
+}
+
+func (s *KubernetesIcingaTestSuite) TestControlPlanePod() {
+	a := assert.New(s.T())
+	c := s.Controller
+
+	_, err := c.Kubernetes.CoreV1().Pods("kube-system").Create(&corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "etcd-master1",
+			Namespace:       "kube-system",
+			Labels:          map[string]string{"component": "etcd", "tier": "control-plane"},
+			Annotations:     map[string]string{"kubernetes.io/config.mirror": "2a5fbe3c"},
+			OwnerReferences: []metav1.OwnerReference{{APIVersion: "v1", Kind: "Node", Name: "master1"}},
+		},
+	})
+	if !a.Nil(err) {
+		return
+	}
+
+	if err := c.simulate(); !a.Nil(err) {
+		return
+	}
+
+	pod, err := s.GetCheckable(s, "testing.infrastructure", "cp-etcd-master1")
+	if !a.Nil(err) {
+		return
+	}
+	a.Equal("pod", pod.GetVars()[VarType])
+	a.Equal("kube-system", pod.GetVars()[VarNamespace])
+
+	if err := c.Kubernetes.CoreV1().Pods("kube-system").Delete("etcd-master1", &metav1.DeleteOptions{}); !a.Nil(err) {
+		return
+	}
+
+	if err := c.simulate(); !a.Nil(err) {
+		return
+	}
+
+	if _, err := s.GetCheckable(s, "testing.infrastructure", "cp-etcd-master1"); !a.NotNil(err) {
+		return
+	}
+}
+
+func (s *KubernetesIcingaTestSuite) TestStuckDeletions() {
+	a := assert.New(s.T())
+	c := s.Controller
+
+	c.StuckDeletionGrace = 10 * time.Minute
+
+	deleted := metav1.NewTime(time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC))
+	recent := metav1.Now()
+
+	_, err := c.Kubernetes.CoreV1().Namespaces().Create(&corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{Name: "team-a", DeletionTimestamp: &deleted},
+		Spec:       corev1.NamespaceSpec{Finalizers: []corev1.FinalizerName{corev1.FinalizerKubernetes}},
+	})
+	if !a.Nil(err) {
+		return
+	}
+
+	_, err = c.Kubernetes.CoreV1().Pods("default").Create(&corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "web-1", Namespace: "default", DeletionTimestamp: &recent},
+	})
+	if !a.Nil(err) {
+		return
+	}
+
+	if err := c.simulate(); !a.Nil(err) {
+		return
+	}
+
+	c.refreshStuckDeletions()
+
+	if err := c.simulate(); !a.Nil(err) {
+		return
+	}
+
+	host, name := c.Mapping.ClusterCheck(c, "stuck-deletions")
+	service := c.Tag + "." + host + "!" + name
+
+	check, err := c.Icinga.GetService(service)
+	if !a.Nil(err) {
+		return
+	}
+	a.Equal("passive", check.GetCheckCommand())
+
+	if r, ok := c.IcingaAPI.(*mockIcingaAPI).result(service); a.True(ok, "no check result for %s", service) {
+		a.Equal(int(health.Critical), r.ExitStatus)
+		a.Equal("1 objects stuck in deletion: namespace team-a since 2019-01-01T00:00:00Z (finalizers: kubernetes)", r.Output)
+	}
+}
+
+func (s *KubernetesIcingaTestSuite) TestEvents() {
+	a := assert.New(s.T())
+	c := s.Controller
+
+	c.EventWindow = 15 * time.Minute
+	c.EventWarning = 5
+	c.EventCritical = 20
+	c.EventIgnoredReasons = []string{"FailedMount"}
+
+	event := func(name, reason string, count int32, source string) *corev1.Event {
+		return &corev1.Event{
+			ObjectMeta:     metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Type:           corev1.EventTypeWarning,
+			Reason:         reason,
+			InvolvedObject: corev1.ObjectReference{Kind: "Pod", Name: "web-1", Namespace: "default"},
+			Source:         corev1.EventSource{Component: source},
+			Count:          count,
+			FirstTimestamp: metav1.Now(),
+			LastTimestamp:  metav1.Now(),
+		}
+	}
+
+	for _, e := range []*corev1.Event{
+		event("web-1.1", "BackOff", 6, "kubelet"),
+		event("web-1.2", "FailedMount", 10, "kubelet"),
+		event("web-1.3", "", 10, EventSource),
+	} {
+		if _, err := c.Kubernetes.CoreV1().Events("default").Create(e); !a.Nil(err) {
+			return
+		}
+	}
+
+	if err := c.simulate(); !a.Nil(err) {
+		return
+	}
+
+	if err := c.processNamespaceEvents("default"); !a.Nil(err) {
+		return
+	}
+
+	if err := c.simulate(); !a.Nil(err) {
+		return
+	}
+
+	namespace, err := c.NamespaceLister.Get("default")
+	if !a.Nil(err) {
+		return
+	}
+
+	host, name := c.Mapping.NamespaceCheck(c, namespace, "events")
+	service := c.Tag + "." + host + "!" + name
+
+	check, err := c.Icinga.GetService(service)
+	if !a.Nil(err) {
+		return
+	}
+	a.Equal("passive", check.GetCheckCommand())
+
+	if r, ok := c.IcingaAPI.(*mockIcingaAPI).result(service); a.True(ok, "no check result for %s", service) {
+		a.Equal(int(health.Warning), r.ExitStatus)
+		a.Equal("namespace default: 6 warning events, reasons: BackOff (6), objects: pod/web-1 (6)", r.Output)
+	}
+
+	// Unmonitored namespaces without an events check are not deleted again and again.
+	if _, err := c.Kubernetes.CoreV1().Namespaces().Create(&corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{Name: "quiet", Annotations: map[string]string{AnnDisableMonitoring: "true"}},
+	}); !a.Nil(err) {
+		return
+	}
+
+	if err := c.simulate(); !a.Nil(err) {
+		return
+	}
+
+	icinga := c.IcingaClient.(*icingafake.Clientset)
+	icinga.ClearActions()
+	if err := c.processNamespaceEvents("quiet"); !a.Nil(err) {
+		return
+	}
+
+	for _, action := range icinga.Actions() {
+		a.NotEqual("delete", action.GetVerb(), "unexpected %s of %s", action.GetVerb(), action.GetResource().Resource)
+	}
+}
+
+func (s *KubernetesIcingaTestSuite) TestNodeConditions() {
+	a := assert.New(s.T())
+	c := s.Controller
+
+	c.NodeConditionChecks = true
+	c.NodeLeaseGrace = 40 * time.Second
+
+	renewed := metav1.NewMicroTime(time.Now().Add(-time.Minute))
+	_, err := c.Kubernetes.CoordinationV1().Leases(corev1.NamespaceNodeLease).Create(&coordinationv1.Lease{
+		ObjectMeta: metav1.ObjectMeta{Name: "node1", Namespace: corev1.NamespaceNodeLease},
+		Spec:       coordinationv1.LeaseSpec{RenewTime: &renewed},
+	})
+	if !a.Nil(err) {
+		return
+	}
+
+	node, err := c.Kubernetes.CoreV1().Nodes().Create(&corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "node1", UID: "node1-uid"},
+		Spec:       corev1.NodeSpec{Unschedulable: true},
+		Status: corev1.NodeStatus{
+			Conditions: []corev1.NodeCondition{
+				{Type: corev1.NodeReady, Status: corev1.ConditionTrue},
+				{Type: corev1.NodeMemoryPressure, Status: corev1.ConditionFalse},
+				{Type: "KernelDeadlock", Status: corev1.ConditionTrue, Message: "task docker blocked"},
+			},
+		},
+	})
+	if !a.Nil(err) {
+		return
+	}
+
+	if err := c.simulate(); !a.Nil(err) {
+		return
+	}
+
+	service := func(host, name string) string {
+		return c.Tag + "." + host + "!" + name
+	}
+
+	tests := []struct {
+		service string
+		state   health.State
+	}{
+		{service(c.Mapping.NodeAttachedCheck(c, node, "ready")), health.OK},
+		{service(c.Mapping.NodeAttachedCheck(c, node, "memorypressure")), health.OK},
+		{service(c.Mapping.NodeAttachedCheck(c, node, "kerneldeadlock")), health.Warning},
+		{service(c.Mapping.NodeAttachedCheck(c, node, "heartbeat")), health.Critical},
+		{service(c.Mapping.NodeAttachedCheck(c, node, "schedulable")), health.Warning},
+	}
+
+	for _, test := range tests {
+		if _, err := c.Icinga.GetService(test.service); !a.Nil(err, test.service) {
+			continue
+		}
+		if r, ok := c.IcingaAPI.(*mockIcingaAPI).result(test.service); a.True(ok, "no check result for %s", test.service) {
+			a.Equal(int(test.state), r.ExitStatus, test.service)
+		}
+	}
+
+	// Conditions that are no longer reported are no longer checked.
+	node.Status.Conditions = node.Status.Conditions[:2]
+	if _, err := c.Kubernetes.CoreV1().Nodes().Update(node); !a.Nil(err) {
+		return
+	}
+
+	if err := c.simulate(); !a.Nil(err) {
+		return
+	}
+
+	if _, err := c.Icinga.GetService(service(c.Mapping.NodeAttachedCheck(c, node, "kerneldeadlock"))); !a.NotNil(err) {
+		return
+	}
+	if _, err := c.Icinga.GetService(service(c.Mapping.NodeAttachedCheck(c, node, "ready"))); !a.Nil(err) {
+		return
+	}
+
+	// The checks of a node whose name starts with our check prefix are left alone.
+	other, err := c.Kubernetes.CoreV1().Nodes().Create(&corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "node1-condition-x", UID: "other-uid"},
+		Status: corev1.NodeStatus{
+			Conditions: []corev1.NodeCondition{{Type: corev1.NodeReady, Status: corev1.ConditionTrue}},
+		},
+	})
+	if !a.Nil(err) {
+		return
+	}
+
+	if err := c.simulate(); !a.Nil(err) {
+		return
+	}
+
+	node.Status.Conditions = node.Status.Conditions[:1]
+	if _, err := c.Kubernetes.CoreV1().Nodes().Update(node); !a.Nil(err) {
+		return
+	}
+
+	if err := c.simulate(); !a.Nil(err) {
+		return
+	}
+
+	if _, err := c.Icinga.GetService(service(c.Mapping.NodeAttachedCheck(c, node, "memorypressure"))); !a.NotNil(err) {
+		return
+	}
+	_, err = c.Icinga.GetService(service(c.Mapping.NodeAttachedCheck(c, other, "ready")))
+	a.Nil(err, "checks of other nodes should be kept")
+}
+
+func (s *KubernetesIcingaTestSuite) TestHorizontalPodAutoscaler() {
+	a := assert.New(s.T())
+	c := s.Controller
+
+	c.CheckMode = CheckModePassive
+
+	deployment, err := c.Kubernetes.AppsV1().Deployments("default").Create(&appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "web",
+		},
+	})
+	if !a.Nil(err) {
+		return
+	}
+
+	hpa := func(name, target string) *autoscalingv2beta2.HorizontalPodAutoscaler {
+		return &autoscalingv2beta2.HorizontalPodAutoscaler{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: name,
+			},
+			Spec: autoscalingv2beta2.HorizontalPodAutoscalerSpec{
+				ScaleTargetRef: autoscalingv2beta2.CrossVersionObjectReference{APIVersion: "apps/v1", Kind: "Deployment", Name: target},
+				MaxReplicas:    5,
+			},
+			Status: autoscalingv2beta2.HorizontalPodAutoscalerStatus{
+				CurrentReplicas: 1,
+				DesiredReplicas: 1,
+				Conditions: []autoscalingv2beta2.HorizontalPodAutoscalerCondition{{
+					Type:    autoscalingv2beta2.ScalingActive,
+					Status:  corev1.ConditionFalse,
+					Reason:  "FailedGetResourceMetric",
+					Message: "unable to get metrics for resource cpu",
+				}},
+			},
+		}
+	}
+
+	if _, err := c.Kubernetes.AutoscalingV2beta2().HorizontalPodAutoscalers("default").Create(hpa("web", "web")); !a.Nil(err) {
+		return
+	}
+	if _, err := c.Kubernetes.AutoscalingV2beta2().HorizontalPodAutoscalers("default").Create(hpa("gone", "gone")); !a.Nil(err) {
+		return
+	}
+
+	if err := c.simulate(); !a.Nil(err) {
+		return
+	}
+
+	host, name := c.Mapping.AttachedCheck(c, deployment, "deploy", "hpa-web")
+	service := c.Tag + "." + host + "!" + name
+
+	check, err := c.Icinga.GetService(service)
+	if !a.Nil(err) {
+		return
+	}
+	a.Equal("horizontalpodautoscaler", check.GetVars()[VarType])
+
+	if r, ok := c.IcingaAPI.(*mockIcingaAPI).result(service); a.True(ok, "no check result for %s", service) {
+		a.Equal(int(health.Critical), r.ExitStatus)
+		a.Contains(r.Output, "scaling is not active: FailedGetResourceMetric")
+	}
+
+	// Without a target, there is nothing to attach the check to.
+	if _, err := c.IcingaClient.IcingaV1().Checks("default").Get("hpa-gone", metav1.GetOptions{}); !a.NotNil(err) {
+		return
+	}
+
+	if err := c.Kubernetes.AutoscalingV2beta2().HorizontalPodAutoscalers("default").Delete("web", &metav1.DeleteOptions{}); !a.Nil(err) {
+		return
+	}
+
+	if err := c.simulate(); !a.Nil(err) {
+		return
+	}
+
+	if _, err := c.Icinga.GetService(service); !a.NotNil(err) {
+		return
+	}
+}
+
+func (s *KubernetesIcingaTestSuite) TestPodDisruptionBudget() {
+	a := assert.New(s.T())
+	c := s.Controller
+
+	c.CheckMode = CheckModePassive
+
+	deployment, err := c.Kubernetes.AppsV1().Deployments("default").Create(&appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "web",
+		},
+		Spec: appsv1.DeploymentSpec{
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": "web"}},
+			},
+		},
+	})
+	if !a.Nil(err) {
+		return
+	}
+
+	pdb := func(name, app string) *policyv1beta1.PodDisruptionBudget {
+		return &policyv1beta1.PodDisruptionBudget{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: name,
+			},
+			Spec: policyv1beta1.PodDisruptionBudgetSpec{
+				Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": app}},
+			},
+			Status: policyv1beta1.PodDisruptionBudgetStatus{
+				CurrentHealthy: 1,
+				DesiredHealthy: 2,
+				ExpectedPods:   2,
+			},
+		}
+	}
+
+	if _, err := c.Kubernetes.PolicyV1beta1().PodDisruptionBudgets("default").Create(pdb("web", "web")); !a.Nil(err) {
+		return
+	}
+	if _, err := c.Kubernetes.PolicyV1beta1().PodDisruptionBudgets("default").Create(pdb("db", "db")); !a.Nil(err) {
+		return
+	}
+
+	if err := c.simulate(); !a.Nil(err) {
+		return
+	}
+
+	// Covering the pods of a single workload, the PodDisruptionBudget is checked next to it.
+	host, name := c.Mapping.AttachedCheck(c, deployment, "deploy", "pdb-web")
+	service := c.Tag + "." + host + "!" + name
+
+	check, err := c.Icinga.GetService(service)
+	if !a.Nil(err) {
+		return
+	}
+	a.Equal("poddisruptionbudget", check.GetVars()[VarType])
+
+	if r, ok := c.IcingaAPI.(*mockIcingaAPI).result(service); a.True(ok, "no check result for %s", service) {
+		a.Equal(int(health.Critical), r.ExitStatus)
+	}
+
+	if _, err := s.GetCheckable(s, "testing.default", "pdb-web"); !a.NotNil(err) {
+		return
+	}
+
+	// Otherwise, it is monitored in its namespace.
+	db, err := s.GetCheckable(s, "testing.default", "pdb-db")
+	if !a.Nil(err) {
+		return
+	}
+	a.Equal("poddisruptionbudget", db.GetVars()[VarType])
+
+	if err := c.Kubernetes.PolicyV1beta1().PodDisruptionBudgets("default").Delete("web", &metav1.DeleteOptions{}); !a.Nil(err) {
+		return
+	}
+	if err := c.Kubernetes.PolicyV1beta1().PodDisruptionBudgets("default").Delete("db", &metav1.DeleteOptions{}); !a.Nil(err) {
+		return
+	}
+
+	if err := c.simulate(); !a.Nil(err) {
+		return
+	}
+
+	if _, err := c.Icinga.GetService(service); !a.NotNil(err) {
+		return
+	}
+	if _, err := s.GetCheckable(s, "testing.default", "pdb-db"); !a.NotNil(err) {
+		return
+	}
+}
+
+// The namespace is looked up once, not for every workload of the namespace.
+func TestDisruptionTarget(t *testing.T) {
+	a := assert.New(t)
+
+	kube := fake.NewSimpleClientset(&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "default"}})
+	gets := 0
+	kube.PrependReactor("get", "namespaces", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		gets++
+		return false, nil, nil
+	})
+
+	deployments := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	replicasets := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	empty := func() cache.Indexer {
+		return cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	}
+
+	c := &Controller{
+		Kubernetes:        kube,
+		DeploymentLister:  appslisterv1.NewDeploymentLister(deployments),
+		ReplicaSetLister:  appslisterv1.NewReplicaSetLister(replicasets),
+		StatefulSetLister: appslisterv1.NewStatefulSetLister(empty()),
+		DaemonSetLister:   appslisterv1.NewDaemonSetLister(empty()),
+	}
+
+	template := func(app string) corev1.PodTemplateSpec {
+		return corev1.PodTemplateSpec{ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": app}}}
+	}
+
+	for _, app := range []string{"web", "db", "cache"} {
+		deployments.Add(&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: app, Namespace: "default"},
+			Spec:       appsv1.DeploymentSpec{Template: template(app)},
+		})
+	}
+	// Owned by the web deployment, so not a candidate.
+	replicasets.Add(&appsv1.ReplicaSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "web-1",
+			Namespace:       "default",
+			OwnerReferences: []metav1.OwnerReference{{Kind: "Deployment", Name: "web"}},
+		},
+		Spec: appsv1.ReplicaSetSpec{Template: template("web")},
+	})
+
+	pdb := &policyv1beta1.PodDisruptionBudget{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"},
+		Spec: policyv1beta1.PodDisruptionBudgetSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}},
+		},
+	}
+
+	target, abbrev := c.disruptionTarget(pdb)
+	if a.NotNil(target) {
+		a.Equal("web", target.GetName())
+		a.Equal("deploy", abbrev)
+	}
+	a.Equal(1, gets)
+}
+
+// A PEM encoded self-signed certificate for www.example.com.
+func testCertificate(notAfter time.Time) []byte {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		panic(err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "www.example.com"},
+		DNSNames:     []string{"www.example.com"},
+		NotBefore:    time.Now().Add(-24 * time.Hour),
+		NotAfter:     notAfter,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		panic(err)
+	}
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
+
+func (s *KubernetesIcingaTestSuite) TestTLSSecret() {
+	a := assert.New(s.T())
+	c := s.Controller
+
+	c.CheckMode = CheckModePassive
+	c.TLSWarningDays = 30
+	c.TLSCriticalDays = 14
+
+	secret := func(name string, annotations map[string]string) *corev1.Secret {
+		return &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        name,
+				Annotations: annotations,
+			},
+			Type: corev1.SecretTypeTLS,
+			Data: map[string][]byte{
+				corev1.TLSCertKey: testCertificate(time.Now().Add(20*24*time.Hour + time.Hour)),
+			},
+		}
+	}
+
+	if _, err := c.Kubernetes.CoreV1().Secrets("default").Create(secret("checked", map[string]string{AnnTLSCheck: "true"})); !a.Nil(err) {
+		return
+	}
+	if _, err := c.Kubernetes.CoreV1().Secrets("default").Create(secret("unchecked", nil)); !a.Nil(err) {
+		return
+	}
+
+	if err := c.simulate(); !a.Nil(err) {
+		return
+	}
+
+	tls, err := s.GetCheckable(s, "testing.default", "tls-checked")
+	if !a.Nil(err) {
+		return
+	}
+	a.Equal("secret", tls.GetVars()[VarType])
+
+	if _, err := s.GetCheckable(s, "testing.default", "tls-unchecked"); !a.NotNil(err) {
+		return
+	}
+
+	typ, name := c.Mapping.WorkloadCheckable(c, &metav1.ObjectMeta{Name: "checked", Namespace: "default"}, "tls")
+	warning := int(health.Warning)
+	if typ == "Host" {
+		warning = 0
+	}
+
+	if r, ok := c.IcingaAPI.(*mockIcingaAPI).result(name); a.True(ok, "no check result for %s", name) {
+		a.Equal(warning, r.ExitStatus)
+		a.Contains(r.Output, "secret checked: certificate CN=www.example.com (www.example.com) expires at")
+		a.Contains(r.Output, "in 20 days")
+	}
+
+	if err := c.Kubernetes.CoreV1().Secrets("default").Delete("checked", &metav1.DeleteOptions{}); !a.Nil(err) {
+		return
+	}
+
+	if err := c.simulate(); !a.Nil(err) {
+		return
+	}
+
+	if _, err := s.GetCheckable(s, "testing.default", "tls-checked"); !a.NotNil(err) {
+		return
+	}
+}
+
+func (s *KubernetesIcingaTestSuite) TestHelmRelease() {
+	a := assert.New(s.T())
+	c := s.Controller
+
+	c.CheckMode = CheckModePassive
+	c.HelmPendingGrace = 10 * time.Minute
+
+	secret := func(revision int, status string) *corev1.Secret {
+		record := fmt.Sprintf(`{"name":"web","namespace":"default","version":%d,"info":{"status":"%s"},`+
+			`"chart":{"metadata":{"name":"nginx","version":"1.2.3"}}}`, revision, status)
+
+		return &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   fmt.Sprintf("sh.helm.release.v1.web.v%d", revision),
+				Labels: map[string]string{"owner": "helm", "name": "web", "status": status, "version": strconv.Itoa(revision)},
+			},
+			Type: helmReleaseSecretType,
+			Data: map[string][]byte{"release": []byte(base64.StdEncoding.EncodeToString([]byte(record)))},
+		}
+	}
+
+	for _, secret := range []*corev1.Secret{secret(1, "superseded"), secret(2, "failed")} {
+		if _, err := c.Kubernetes.CoreV1().Secrets("default").Create(secret); !a.Nil(err) {
+			return
+		}
+	}
+
+	if err := c.simulate(); !a.Nil(err) {
+		return
+	}
+
+	release, err := s.GetCheckable(s, "testing.default", "helm-web")
+	if !a.Nil(err) {
+		return
+	}
+	a.Equal("helmrelease", release.GetVars()[VarType])
+	a.Equal("nginx", release.GetVars()[VarHelmChart])
+	a.Equal("1.2.3", release.GetVars()[VarHelmChartVersion])
+	a.Equal("2", release.GetVars()[VarHelmRevision])
+
+	typ, name := c.Mapping.WorkloadCheckable(c, &metav1.ObjectMeta{Name: "web", Namespace: "default"}, "helm")
+	critical := int(health.Critical)
+	if typ == "Host" {
+		critical = 1
+	}
+
+	if r, ok := c.IcingaAPI.(*mockIcingaAPI).result(name); a.True(ok, "no check result for %s", name) {
+		a.Equal(critical, r.ExitStatus)
+		a.Equal("helm release web: revision 2 of chart nginx-1.2.3 failed", r.Output)
+	}
+
+	for _, secret := range []string{"sh.helm.release.v1.web.v1", "sh.helm.release.v1.web.v2"} {
+		if err := c.Kubernetes.CoreV1().Secrets("default").Delete(secret, &metav1.DeleteOptions{}); !a.Nil(err) {
+			return
+		}
+	}
+
+	if err := c.simulate(); !a.Nil(err) {
+		return
+	}
+
+	if _, err := s.GetCheckable(s, "testing.default", "helm-web"); !a.NotNil(err) {
+		return
+	}
+}
+
+func (s *KubernetesIcingaTestSuite) TestAPIService() {
+	a := assert.New(s.T())
+	c := s.Controller
+
+	c.CheckMode = CheckModePassive
+
+	apiService := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "apiregistration.k8s.io/v1",
+		"kind":       "APIService",
+		"metadata": map[string]interface{}{
+			"name": "v1beta1.metrics.k8s.io",
+		},
+		"status": map[string]interface{}{
+			"conditions": []interface{}{
+				map[string]interface{}{"type": "Available", "status": "False", "message": "endpoints for service/metrics-server in \"kube-system\" have no addresses"},
+			},
+		},
+	}}
+
+	if err := c.APIServiceCreatedOrUpdated(apiService); !a.Nil(err) {
+		return
+	}
+
+	if err := c.simulate(); !a.Nil(err) {
+		return
+	}
+
+	checkable, err := s.GetCheckable(s, "testing.infrastructure", "apiservice-v1beta1.metrics.k8s.io")
+	if !a.Nil(err) {
+		return
+	}
+	a.Equal("apiservice", checkable.GetVars()[VarType])
+
+	typ, name := c.Mapping.InfrastructureCheckable(c, apiService, "apiservice")
+	if r, ok := c.IcingaAPI.(*mockIcingaAPI).result(name); a.True(ok, "no check result for %s", name) {
+		if typ == "Host" {
+			a.Equal(1, r.ExitStatus)
+		} else {
+			a.Equal(int(health.Critical), r.ExitStatus)
+		}
+		a.Contains(r.Output, "apiservice v1beta1.metrics.k8s.io: Available is False")
+	}
+
+	if err := c.APIServiceDeleted(apiService); !a.Nil(err) {
+		return
+	}
+
+	if err := c.simulate(); !a.Nil(err) {
+		return
+	}
+
+	if _, err := s.GetCheckable(s, "testing.infrastructure", "apiservice-v1beta1.metrics.k8s.io"); !a.NotNil(err) {
+		return
+	}
+}
+
+func (s *KubernetesIcingaTestSuite) TestWebhookConfiguration() {
+	a := assert.New(s.T())
+	c := s.Controller
+
+	c.CheckMode = CheckModePassive
+
+	wc, err := c.Kubernetes.AdmissionregistrationV1().ValidatingWebhookConfigurations().Create(&admissionregistrationv1.ValidatingWebhookConfiguration{
+		ObjectMeta: metav1.ObjectMeta{Name: "policies"},
+		Webhooks: []admissionregistrationv1.ValidatingWebhook{{
+			Name:         "validate.policies.example.com",
+			ClientConfig: admissionregistrationv1.WebhookClientConfig{Service: &admissionregistrationv1.ServiceReference{Namespace: "default", Name: "webhook"}},
+		}},
+	})
+	if !a.Nil(err) {
+		return
+	}
+
+	if err := c.simulate(); !a.Nil(err) {
+		return
+	}
+
+	if _, err := s.GetCheckable(s, "testing.infrastructure", "vwc-policies"); !a.Nil(err) {
+		return
+	}
+
+	typ, name := c.Mapping.InfrastructureCheckable(c, wc, "vwc")
+	critical := int(health.Critical)
+	if typ == "Host" {
+		critical = 1
+	}
+
+	if r, ok := c.IcingaAPI.(*mockIcingaAPI).result(name); a.True(ok, "no check result for %s", name) {
+		a.Equal(critical, r.ExitStatus)
+		a.Contains(r.Output, "service default/webhook has no ready endpoints (failurePolicy Fail)")
+	}
+
+	// The configuration is checked again when the endpoints of the webhook change.
+	_, err = c.Kubernetes.CoreV1().Endpoints("default").Create(&corev1.Endpoints{
+		ObjectMeta: metav1.ObjectMeta{Name: "webhook"},
+		Subsets:    []corev1.EndpointSubset{{Addresses: []corev1.EndpointAddress{{IP: "10.0.0.1"}}}},
+	})
+	if !a.Nil(err) {
+		return
+	}
+
+	if err := c.simulate(); !a.Nil(err) {
+		return
+	}
+
+	if r, ok := c.IcingaAPI.(*mockIcingaAPI).result(name); a.True(ok, "no check result for %s", name) {
+		a.Equal(0, r.ExitStatus)
+	}
+}
+
+func (s *KubernetesIcingaTestSuite) TestIngress() {
+	a := assert.New(s.T())
+	c := s.Controller
+
+	c.CheckMode = CheckModePassive
+	ingress := &networkingv1beta1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "mying",
+			Namespace:   "default",
+			UID:         "mying-uid",
+			Annotations: map[string]string{AnnHTTPChecks: "true"},
+		},
+		Spec: networkingv1beta1.IngressSpec{
+			TLS: []networkingv1beta1.IngressTLS{{Hosts: []string{"www.example.com"}}},
+			Rules: []networkingv1beta1.IngressRule{
+				{
+					Host: "www.example.com",
+					IngressRuleValue: networkingv1beta1.IngressRuleValue{
+						HTTP: &networkingv1beta1.HTTPIngressRuleValue{
+							Paths: []networkingv1beta1.HTTPIngressPath{
+								{Path: "/", Backend: networkingv1beta1.IngressBackend{ServiceName: "web"}},
+								{Path: "/api", Backend: networkingv1beta1.IngressBackend{ServiceName: "api"}},
+							},
+						},
+					},
+				},
+				{Host: "plain.example.com"},
+				{Host: "*.example.com"},
+			},
+		},
+	}
+
+	_, err := c.Kubernetes.NetworkingV1beta1().Ingresses("default").Create(ingress)
+	if !a.Nil(err) {
+		return
+	}
+
+	if err := c.simulate(); !a.Nil(err) {
+		return
+	}
+
+	ing, err := s.GetCheckable(s, "testing.default", "ing-mying")
+	if !a.Nil(err) {
+		return
+	}
+	a.Equal("ingress", ing.GetVars()[VarType])
+
+	httpCheck := func(checkName string) (icinga2.Checkable, error) {
+		host, name := c.Mapping.AttachedCheck(c, ingress, "ing", "http-"+checkName)
+		return c.Icinga.GetService(c.Tag + "." + host + "!" + name)
+	}
+
+	certificateCheck := func(checkName string) (icinga2.Checkable, error) {
+		host, name := c.Mapping.AttachedCheck(c, ingress, "ing", "certificate-"+checkName)
+		return c.Icinga.GetService(c.Tag + "." + host + "!" + name)
+	}
+
+	if check, err := httpCheck("www.example.com"); a.Nil(err) {
+		a.Equal("http", check.GetCheckCommand())
+		a.Equal("www.example.com", check.GetVars()["http_vhost"])
+		a.Equal("/", check.GetVars()["http_uri"])
+		a.Equal("true", check.GetVars()["http_ssl"])
+		a.Nil(check.GetVars()["http_certificate"], "the URI check must not be a certificate check")
+	}
+
+	if check, err := certificateCheck("www.example.com"); a.Nil(err) {
+		a.Equal("http", check.GetCheckCommand())
+		a.Equal("www.example.com", check.GetVars()["http_vhost"])
+		a.Equal("true", check.GetVars()["http_sni"])
+		a.Equal(httpCheckCertificateDays, check.GetVars()["http_certificate"])
+	}
+
+	_, err = certificateCheck("plain.example.com")
+	a.NotNil(err, "hosts without TLS have no certificate check")
+
+	if check, err := httpCheck("www.example.com-api"); a.Nil(err) {
+		a.Equal("/api", check.GetVars()["http_uri"])
+	}
+
+	if check, err := httpCheck("plain.example.com"); a.Nil(err) {
+		a.Equal("/", check.GetVars()["http_uri"])
+		a.Nil(check.GetVars()["http_ssl"])
+	}
+
+	checks, err := c.IcingaClient.IcingaV1().Checks("default").List(metav1.ListOptions{})
+	if a.Nil(err) {
+		var names []string
+		for _, ch := range checks.Items {
+			names = append(names, ch.Name)
+		}
+		a.Subset(names, []string{"ing-mying-http-www.example.com", "ing-mying-http-www.example.com-api", "ing-mying-http-plain.example.com", "ing-mying-certificate-www.example.com"})
+		a.NotContains(names, "ing-mying-http-example.com")
+	}
+
+	// Checks for paths that are removed are deleted.
+	ingress.Spec.Rules[0].HTTP.Paths = ingress.Spec.Rules[0].HTTP.Paths[:1]
+	if _, err := c.Kubernetes.NetworkingV1beta1().Ingresses("default").Update(ingress); !a.Nil(err) {
+		return
+	}
+
+	if err := c.simulate(); !a.Nil(err) {
+		return
+	}
+
+	_, err = httpCheck("www.example.com-api")
+	a.NotNil(err, "check for removed path should be deleted")
+
+	_, err = httpCheck("www.example.com")
+	a.Nil(err)
+
+	// The checks of an ingress whose name starts with our check prefix are left alone.
+	other := &networkingv1beta1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "mying-http-other",
+			Namespace:   "default",
+			UID:         "other-uid",
+			Annotations: map[string]string{AnnHTTPChecks: "true"},
+		},
+		Spec: networkingv1beta1.IngressSpec{
+			Rules: []networkingv1beta1.IngressRule{{Host: "other.example.com"}},
+		},
+	}
+	if _, err := c.Kubernetes.NetworkingV1beta1().Ingresses("default").Create(other); !a.Nil(err) {
+		return
+	}
+
+	// Without the annotation, all checks are deleted.
+	ingress.Annotations = nil
+	if _, err := c.Kubernetes.NetworkingV1beta1().Ingresses("default").Update(ingress); !a.Nil(err) {
+		return
+	}
+
+	if err := c.simulate(); !a.Nil(err) {
+		return
+	}
+
+	_, err = httpCheck("www.example.com")
+	a.NotNil(err, "checks should be deleted without the annotation")
+
+	_, err = certificateCheck("www.example.com")
+	a.NotNil(err, "certificate checks should be deleted without the annotation")
+
+	_, err = c.IcingaClient.IcingaV1().Checks("default").Get("ing-mying-http-other-http-other.example.com", metav1.GetOptions{})
+	a.Nil(err, "checks of other ingresses should be kept")
+
+	_, err = s.GetCheckable(s, "testing.default", "ing-mying")
+	a.Nil(err, "the ingress itself is still monitored")
+}
+
+func TestHTTPCheckName(t *testing.T) {
+	assert.Equal(t, "www.example.com", httpCheckName("www.example.com", "/"))
+	assert.Equal(t, "www.example.com-api-v1", httpCheckName("WWW.example.com", "/api/v1/"))
+	assert.Equal(t, "www.example.com-well-known", httpCheckName("www.example.com", "/.well-known"))
+}
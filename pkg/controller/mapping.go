@@ -16,6 +16,39 @@ type Mapping interface {
 	UnmonitorNode(c *Controller, node *corev1.Node) error
 	MonitorComponentStatus(c *Controller, cs *corev1.ComponentStatus) error
 	UnmonitorComponentStatus(c *Controller, cs *corev1.ComponentStatus) error
+	MonitorAPIServer(c *Controller) error
+	MonitorClusterChecks(c *Controller) error
+	MonitorNamespaceChecks(c *Controller, namespace *corev1.Namespace) error
+	MonitorControlPlanePod(c *Controller, pod *corev1.Pod) error
+	UnmonitorControlPlanePod(c *Controller, pod *corev1.Pod) error
+	MonitorInfrastructure(c *Controller, o metav1.Object, abbrev, typ, kind, apiVersion string) error
+	UnmonitorInfrastructure(c *Controller, o metav1.Object, abbrev string) error
+	MonitorPersistentVolume(c *Controller, pv *corev1.PersistentVolume) error
+	UnmonitorPersistentVolume(c *Controller, pv *corev1.PersistentVolume) error
 	MonitorWorkload(c *Controller, o metav1.Object, abbrev, typ, kind, apiVersion string) error
 	UnmonitorWorkload(c *Controller, o metav1.Object, abbrev string) error
+
+	// The type ("Host" or "Service") and full name of the Icinga object that monitors a workload, node,
+	// componentstatus, control plane pod, persistentvolume or other infrastructure.
+	WorkloadCheckable(c *Controller, o metav1.Object, abbrev string) (string, string)
+	InfrastructureCheckable(c *Controller, o metav1.Object, abbrev string) (string, string)
+	NodeCheckable(c *Controller, node *corev1.Node) (string, string)
+	ComponentStatusCheckable(c *Controller, cs *corev1.ComponentStatus) (string, string)
+	ControlPlanePodCheckable(c *Controller, pod *corev1.Pod) (string, string)
+	PersistentVolumeCheckable(c *Controller, pv *corev1.PersistentVolume) (string, string)
+
+	// The host (without the tag) and name of an additional check named name for a workload.
+	AttachedCheck(c *Controller, o metav1.Object, abbrev, name string) (string, string)
+
+	// The host (without the tag) and name of an additional check named name for a node.
+	NodeAttachedCheck(c *Controller, node *corev1.Node, name string) (string, string)
+
+	// The host (without the tag) and name of a check named name for the API server.
+	APIServerCheck(c *Controller, name string) (string, string)
+
+	// The host (without the tag) and name of a check named name about the cluster as a whole.
+	ClusterCheck(c *Controller, name string) (string, string)
+
+	// The host (without the tag) and name of a check named name about a namespace as a whole.
+	NamespaceCheck(c *Controller, namespace *corev1.Namespace, name string) (string, string)
 }
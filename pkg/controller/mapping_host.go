@@ -100,9 +100,9 @@ func (m *HostMapping) MonitorNode(c *Controller, node *corev1.Node) error {
 		ObjectMeta: MakeObjectMeta(node, "Node", "v1", "", true),
 		Spec: icingav1.CheckSpec{
 			Host:         "nodes",
-			CheckCommand: "check_kubernetes",
+			CheckCommand: c.CheckCommand(),
 			Name:         node.Name,
-			Vars:         c.MakeVars(node, "node", false)},
+			Vars:         c.MakeCheckVars(node, "node", false)},
 	})
 }
 
@@ -120,8 +120,8 @@ func (m *HostMapping) MonitorComponentStatus(c *Controller, cs *corev1.Component
 			Spec: icingav1.CheckSpec{
 				Name:         "cs-" + cs.Name,
 				Host:         "infrastructure",
-				CheckCommand: "check_kubernetes",
-				Vars:         c.MakeVars(cs, "componentstatus", false),
+				CheckCommand: c.CheckCommand(),
+				Vars:         c.MakeCheckVars(cs, "componentstatus", false),
 			},
 		})
 }
@@ -130,14 +130,78 @@ func (m *HostMapping) UnmonitorComponentStatus(c *Controller, cs *corev1.Compone
 	return c.deleteCheck("kube-system", "cs-"+cs.Name)
 }
 
+// The API server checks are attached to the infrastructure host.
+func (m *HostMapping) MonitorAPIServer(c *Controller) error {
+	return nil
+}
+
+// Checks about the cluster as a whole are attached to the infrastructure host.
+func (m *HostMapping) MonitorClusterChecks(c *Controller) error {
+	return nil
+}
+
+// Checks about a namespace as a whole are attached to the host of the namespace.
+func (m *HostMapping) MonitorNamespaceChecks(c *Controller, namespace *corev1.Namespace) error {
+	return nil
+}
+
+func (m *HostMapping) MonitorControlPlanePod(c *Controller, pod *corev1.Pod) error {
+	return c.reconcileCheck(&icingav1.Check{
+		ObjectMeta: MakeObjectMeta(pod, "Pod", "v1", "cp", true),
+		Spec: icingav1.CheckSpec{
+			Name:         "cp-" + pod.Name,
+			Host:         "infrastructure",
+			CheckCommand: c.CheckCommand(),
+			Vars:         c.MakeCheckVars(pod, "pod", true),
+		},
+	})
+}
+
+func (m *HostMapping) UnmonitorControlPlanePod(c *Controller, pod *corev1.Pod) error {
+	return c.deleteCheck("kube-system", "cp-"+pod.Name)
+}
+
+// Cluster scoped objects like APIServices are checks on the infrastructure host.
+func (m *HostMapping) MonitorInfrastructure(c *Controller, o metav1.Object, abbrev, typ, kind, apiVersion string) error {
+	return c.reconcileCheck(&icingav1.Check{
+		ObjectMeta: MakeObjectMeta(o, kind, apiVersion, abbrev, true),
+		Spec: icingav1.CheckSpec{
+			Name:         fmt.Sprintf("%s-%s", abbrev, o.GetName()),
+			Host:         "infrastructure",
+			CheckCommand: c.CheckCommand(),
+			Vars:         c.MakeCheckVars(o, typ, false),
+		},
+	})
+}
+
+func (m *HostMapping) UnmonitorInfrastructure(c *Controller, o metav1.Object, abbrev string) error {
+	return c.deleteCheck("kube-system", fmt.Sprintf("%s-%s", abbrev, o.GetName()))
+}
+
+func (m *HostMapping) MonitorPersistentVolume(c *Controller, pv *corev1.PersistentVolume) error {
+	return c.reconcileCheck(&icingav1.Check{
+		ObjectMeta: MakeObjectMeta(pv, "PersistentVolume", "v1", "pv", true),
+		Spec: icingav1.CheckSpec{
+			Name:         "pv-" + pv.Name,
+			Host:         "infrastructure",
+			CheckCommand: c.CheckCommand(),
+			Vars:         c.MakeCheckVars(pv, "persistentvolume", false),
+		},
+	})
+}
+
+func (m *HostMapping) UnmonitorPersistentVolume(c *Controller, pv *corev1.PersistentVolume) error {
+	return c.deleteCheck("kube-system", "pv-"+pv.Name)
+}
+
 func (m *HostMapping) MonitorWorkload(c *Controller, o metav1.Object, abbrev, typ, kind, apiVersion string) error {
 	h := &icingav1.Check{
 		ObjectMeta: MakeObjectMeta(o, kind, apiVersion, abbrev, false),
 		Spec: icingav1.CheckSpec{
 			Host:         o.GetNamespace(),
 			Name:         fmt.Sprintf("%s-%s", abbrev, o.GetName()),
-			CheckCommand: "check_kubernetes",
-			Vars:         c.MakeVars(o, typ, true),
+			CheckCommand: c.CheckCommand(),
+			Vars:         c.MakeCheckVars(o, typ, true),
 		},
 	}
 
@@ -155,3 +219,47 @@ func (m *HostMapping) MonitorWorkload(c *Controller, o metav1.Object, abbrev, ty
 func (m *HostMapping) UnmonitorWorkload(c *Controller, o metav1.Object, abbrev string) error {
 	return c.deleteCheck(o.GetNamespace(), fmt.Sprintf("%s-%s", abbrev, o.GetName()))
 }
+
+func (m *HostMapping) WorkloadCheckable(c *Controller, o metav1.Object, abbrev string) (string, string) {
+	return "Service", fmt.Sprintf("%s.%s!%s-%s", c.Tag, o.GetNamespace(), abbrev, o.GetName())
+}
+
+func (m *HostMapping) NodeCheckable(c *Controller, node *corev1.Node) (string, string) {
+	return "Service", c.Tag + ".nodes!" + node.Name
+}
+
+func (m *HostMapping) ComponentStatusCheckable(c *Controller, cs *corev1.ComponentStatus) (string, string) {
+	return "Service", c.Tag + ".infrastructure!cs-" + cs.Name
+}
+
+func (m *HostMapping) ControlPlanePodCheckable(c *Controller, pod *corev1.Pod) (string, string) {
+	return "Service", c.Tag + ".infrastructure!cp-" + pod.Name
+}
+
+func (m *HostMapping) InfrastructureCheckable(c *Controller, o metav1.Object, abbrev string) (string, string) {
+	return "Service", fmt.Sprintf("%s.infrastructure!%s-%s", c.Tag, abbrev, o.GetName())
+}
+
+func (m *HostMapping) PersistentVolumeCheckable(c *Controller, pv *corev1.PersistentVolume) (string, string) {
+	return "Service", c.Tag + ".infrastructure!pv-" + pv.Name
+}
+
+func (m *HostMapping) AttachedCheck(c *Controller, o metav1.Object, abbrev, name string) (string, string) {
+	return o.GetNamespace(), fmt.Sprintf("%s-%s-%s", abbrev, o.GetName(), name)
+}
+
+func (m *HostMapping) NodeAttachedCheck(c *Controller, node *corev1.Node, name string) (string, string) {
+	return "nodes", node.Name + "-" + name
+}
+
+func (m *HostMapping) APIServerCheck(c *Controller, name string) (string, string) {
+	return "infrastructure", "apiserver-" + name
+}
+
+func (m *HostMapping) ClusterCheck(c *Controller, name string) (string, string) {
+	return "infrastructure", name
+}
+
+func (m *HostMapping) NamespaceCheck(c *Controller, namespace *corev1.Namespace, name string) (string, string) {
+	return namespace.Name, name
+}
@@ -33,6 +33,9 @@ func (m *HostGroupMapping) MonitorNamespace(c *Controller, namespace *corev1.Nam
 }
 
 func (m *HostGroupMapping) UnmonitorNamespace(c *Controller, namespace *corev1.Namespace) error {
+	if err := c.deleteHost(namespace.Name, "namespace"); err != nil {
+		return err
+	}
 	return c.deleteHostGroup("kube-system", namespace.Name)
 }
 
@@ -122,10 +125,10 @@ func (m *HostGroupMapping) MonitorNode(c *Controller, node *corev1.Node) error {
 	return c.reconcileHost(&icingav1.Host{
 		ObjectMeta: MakeObjectMeta(node, "Node", "v1", "", true),
 		Spec: icingav1.HostSpec{
-			CheckCommand: "check_kubernetes",
+			CheckCommand: c.CheckCommand(),
 			Name:         "nodes." + node.Name,
 			Hostgroups:   []string{"nodes"},
-			Vars:         c.MakeVars(node, "node", false)},
+			Vars:         c.MakeCheckVars(node, "node", false)},
 	})
 }
 
@@ -143,8 +146,8 @@ func (m *HostGroupMapping) MonitorComponentStatus(c *Controller, cs *corev1.Comp
 			Spec: icingav1.HostSpec{
 				Name:         "infrastructure.cs-" + cs.Name,
 				Hostgroups:   []string{"infrastructure"},
-				CheckCommand: "check_kubernetes",
-				Vars:         c.MakeVars(cs, "componentstatus", false),
+				CheckCommand: c.CheckCommand(),
+				Vars:         c.MakeCheckVars(cs, "componentstatus", false),
 			},
 		})
 }
@@ -153,14 +156,114 @@ func (m *HostGroupMapping) UnmonitorComponentStatus(c *Controller, cs *corev1.Co
 	return c.deleteHost("kube-system", cs.Name)
 }
 
+func (m *HostGroupMapping) MonitorAPIServer(c *Controller) error {
+	return c.reconcileHost(
+		&icingav1.Host{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "apiserver",
+				Namespace: "kube-system",
+			},
+			Spec: icingav1.HostSpec{
+				Name:         "infrastructure.apiserver",
+				Hostgroups:   []string{"infrastructure"},
+				CheckCommand: "dummy",
+				Vars:         c.MakeVars(&metav1.ObjectMeta{Name: "apiserver"}, "apiserver", false),
+			},
+		})
+}
+
+// Checks about the cluster as a whole, like stuck deletions, are services of a host in the infrastructure hostgroup.
+func (m *HostGroupMapping) MonitorClusterChecks(c *Controller) error {
+	return c.reconcileHost(
+		&icingav1.Host{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "cluster",
+				Namespace: "kube-system",
+			},
+			Spec: icingav1.HostSpec{
+				Name:         "infrastructure.cluster",
+				Hostgroups:   []string{"infrastructure"},
+				CheckCommand: "dummy",
+				Vars:         c.MakeVars(&metav1.ObjectMeta{Name: "cluster"}, "cluster", false),
+			},
+		})
+}
+
+// Checks about a namespace as a whole, like its events, are services of a host in the hostgroup of the namespace.
+func (m *HostGroupMapping) MonitorNamespaceChecks(c *Controller, namespace *corev1.Namespace) error {
+	return c.reconcileHost(
+		&icingav1.Host{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            "namespace",
+				Namespace:       namespace.Name,
+				OwnerReferences: MakeOwnerRef(namespace, "Namespace", "v1"),
+			},
+			Spec: icingav1.HostSpec{
+				Name:         namespace.Name + ".namespace",
+				Hostgroups:   []string{namespace.Name},
+				CheckCommand: "dummy",
+				Vars:         c.MakeVars(namespace, "namespace", false),
+			},
+		})
+}
+
+func (m *HostGroupMapping) MonitorControlPlanePod(c *Controller, pod *corev1.Pod) error {
+	return c.reconcileHost(&icingav1.Host{
+		ObjectMeta: MakeObjectMeta(pod, "Pod", "v1", "cp", true),
+		Spec: icingav1.HostSpec{
+			Name:         "infrastructure.cp-" + pod.Name,
+			Hostgroups:   []string{"infrastructure"},
+			CheckCommand: c.CheckCommand(),
+			Vars:         c.MakeCheckVars(pod, "pod", true),
+		},
+	})
+}
+
+func (m *HostGroupMapping) UnmonitorControlPlanePod(c *Controller, pod *corev1.Pod) error {
+	return c.deleteHost("kube-system", "cp-"+pod.Name)
+}
+
+// Cluster scoped objects like APIServices are hosts in the infrastructure hostgroup.
+func (m *HostGroupMapping) MonitorInfrastructure(c *Controller, o metav1.Object, abbrev, typ, kind, apiVersion string) error {
+	return c.reconcileHost(&icingav1.Host{
+		ObjectMeta: MakeObjectMeta(o, kind, apiVersion, abbrev, true),
+		Spec: icingav1.HostSpec{
+			Name:         fmt.Sprintf("infrastructure.%s-%s", abbrev, o.GetName()),
+			Hostgroups:   []string{"infrastructure"},
+			CheckCommand: c.CheckCommand(),
+			Vars:         c.MakeCheckVars(o, typ, false),
+		},
+	})
+}
+
+func (m *HostGroupMapping) UnmonitorInfrastructure(c *Controller, o metav1.Object, abbrev string) error {
+	return c.deleteHost("kube-system", fmt.Sprintf("%s-%s", abbrev, o.GetName()))
+}
+
+func (m *HostGroupMapping) MonitorPersistentVolume(c *Controller, pv *corev1.PersistentVolume) error {
+	return c.reconcileHost(&icingav1.Host{
+		ObjectMeta: MakeObjectMeta(pv, "PersistentVolume", "v1", "pv", true),
+		Spec: icingav1.HostSpec{
+			Name:         "infrastructure.pv-" + pv.Name,
+			Hostgroups:   []string{"infrastructure"},
+			CheckCommand: c.CheckCommand(),
+			Vars:         c.MakeCheckVars(pv, "persistentvolume", false),
+		},
+	})
+}
+
+func (m *HostGroupMapping) UnmonitorPersistentVolume(c *Controller, pv *corev1.PersistentVolume) error {
+	return c.deleteHost("kube-system", "pv-"+pv.Name)
+}
+
 func (m *HostGroupMapping) MonitorWorkload(c *Controller, o metav1.Object, abbrev, typ, kind, apiVersion string) error {
 	h := &icingav1.Host{
 		ObjectMeta: MakeObjectMeta(o, kind, apiVersion, abbrev, false),
 		Spec: icingav1.HostSpec{
 			Name:         fmt.Sprintf("%s.%s-%s", o.GetNamespace(), abbrev, o.GetName()),
 			Hostgroups:   []string{o.GetNamespace()},
-			CheckCommand: "check_kubernetes",
-			Vars:         c.MakeVars(o, typ, true),
+			CheckCommand: c.CheckCommand(),
+			Vars:         c.MakeCheckVars(o, typ, true),
 		},
 	}
 
@@ -178,3 +281,47 @@ func (m *HostGroupMapping) MonitorWorkload(c *Controller, o metav1.Object, abbre
 func (m *HostGroupMapping) UnmonitorWorkload(c *Controller, o metav1.Object, abbrev string) error {
 	return c.deleteHost(o.GetNamespace(), fmt.Sprintf("%s-%s", abbrev, o.GetName()))
 }
+
+func (m *HostGroupMapping) WorkloadCheckable(c *Controller, o metav1.Object, abbrev string) (string, string) {
+	return "Host", fmt.Sprintf("%s.%s.%s-%s", c.Tag, o.GetNamespace(), abbrev, o.GetName())
+}
+
+func (m *HostGroupMapping) NodeCheckable(c *Controller, node *corev1.Node) (string, string) {
+	return "Host", c.Tag + ".nodes." + node.Name
+}
+
+func (m *HostGroupMapping) ComponentStatusCheckable(c *Controller, cs *corev1.ComponentStatus) (string, string) {
+	return "Host", c.Tag + ".infrastructure.cs-" + cs.Name
+}
+
+func (m *HostGroupMapping) ControlPlanePodCheckable(c *Controller, pod *corev1.Pod) (string, string) {
+	return "Host", c.Tag + ".infrastructure.cp-" + pod.Name
+}
+
+func (m *HostGroupMapping) InfrastructureCheckable(c *Controller, o metav1.Object, abbrev string) (string, string) {
+	return "Host", fmt.Sprintf("%s.infrastructure.%s-%s", c.Tag, abbrev, o.GetName())
+}
+
+func (m *HostGroupMapping) PersistentVolumeCheckable(c *Controller, pv *corev1.PersistentVolume) (string, string) {
+	return "Host", c.Tag + ".infrastructure.pv-" + pv.Name
+}
+
+func (m *HostGroupMapping) AttachedCheck(c *Controller, o metav1.Object, abbrev, name string) (string, string) {
+	return fmt.Sprintf("%s.%s-%s", o.GetNamespace(), abbrev, o.GetName()), name
+}
+
+func (m *HostGroupMapping) NodeAttachedCheck(c *Controller, node *corev1.Node, name string) (string, string) {
+	return "nodes." + node.Name, name
+}
+
+func (m *HostGroupMapping) APIServerCheck(c *Controller, name string) (string, string) {
+	return "infrastructure.apiserver", name
+}
+
+func (m *HostGroupMapping) ClusterCheck(c *Controller, name string) (string, string) {
+	return "infrastructure.cluster", name
+}
+
+func (m *HostGroupMapping) NamespaceCheck(c *Controller, namespace *corev1.Namespace, name string) (string, string) {
+	return namespace.Name + ".namespace", name
+}
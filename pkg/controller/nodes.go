@@ -0,0 +1,49 @@
+package main
+
+import (
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/Nexinto/kubernetes-icinga/pkg/health"
+)
+
+// Create a passive check for every condition of a node, including the ones added by node-problem-detector, and
+// for its heartbeat lease and whether it is cordoned. Checks of conditions the node no longer reports are removed.
+func (c *Controller) processNodeConditions(node *corev1.Node) {
+	prefix := node.Name + "-condition-"
+	checks := make(map[string]health.Result)
+
+	if c.monitored(node) && node.GetDeletionTimestamp() == nil {
+		for _, cond := range node.Status.Conditions {
+			checks[strings.ToLower(string(cond.Type))] = health.NodeCondition(node, cond)
+		}
+
+		lease, err := c.Kubernetes.CoordinationV1().Leases(corev1.NamespaceNodeLease).Get(node.Name, metav1.GetOptions{})
+		if err != nil {
+			if !errors.IsNotFound(err) {
+				log.Errorf("error getting heartbeat lease of node '%s': %s", node.Name, err.Error())
+			}
+			lease = nil
+		}
+		checks["heartbeat"] = health.NodeLease(node, lease, c.NodeLeaseGrace)
+		checks["schedulable"] = health.NodeSchedulable(node)
+	}
+
+	for label, r := range checks {
+		meta := MakeObjectMeta(node, "Node", "v1", "", true)
+		meta.Name = prefix + label
+		host, name := c.Mapping.NodeAttachedCheck(c, node, label)
+
+		if err := c.passiveCheck(meta, host, name, c.MakeVars(node, "node", false), r); err != nil {
+			log.Errorf("error creating %s check for node '%s': %s", label, node.Name, err.Error())
+		}
+	}
+
+	// Another node may have a name that starts with our prefix.
+	c.pruneChecks(node, prefix, checks)
+}
@@ -0,0 +1,213 @@
+package main
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	autoscalingv2beta2 "k8s.io/api/autoscaling/v2beta2"
+	batchv1beta1 "k8s.io/api/batch/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1beta1 "k8s.io/api/networking/v1beta1"
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/Nexinto/kubernetes-icinga/pkg/health"
+)
+
+// The check command for the Icinga objects that monitor workload, nodes and componentstatuses.
+func (c *Controller) CheckCommand() string {
+	switch c.CheckMode {
+	case CheckModePassive:
+		return "passive"
+	case CheckModeHTTP:
+		return c.HTTPCheckCommand
+	default:
+		return "check_kubernetes"
+	}
+}
+
+// The types check_kubernetes cannot check. Objects of these types, and of MONITORED_RESOURCES, are only monitored
+// in passive and http check mode, where we evaluate them ourselves.
+var evaluatedTypes = map[string]bool{
+	"service":                        true,
+	"ingress":                        true,
+	"job":                            true,
+	"cronjob":                        true,
+	"persistentvolumeclaim":          true,
+	"persistentvolume":               true,
+	"horizontalpodautoscaler":        true,
+	"poddisruptionbudget":            true,
+	"resourcequota":                  true,
+	"apiservice":                     true,
+	"validatingwebhookconfiguration": true,
+	"mutatingwebhookconfiguration":   true,
+	"secret":                         true,
+	"helmrelease":                    true,
+}
+
+// True if objects of a type can be checked in the current check mode.
+func (c *Controller) checkable(typ string) bool {
+	if c.CheckMode == CheckModePassive || c.CheckMode == CheckModeHTTP {
+		return true
+	}
+	return !evaluatedTypes[typ] && c.monitoredResourceByType(typ) == nil
+}
+
+// In passive mode, evaluate the health of an object and send the result to its Icinga host or service.
+func (c *Controller) submitCheckResult(typ, name string, o interface{}) {
+	if c.CheckMode != CheckModePassive {
+		return
+	}
+
+	c.processCheckResult(typ, name, c.evaluate(o))
+}
+
+// Send a check result to an Icinga host or service. Icinga objects are created asynchronously from our CRs, so
+// the first result for a new object may arrive before the object exists. Those results are retried a few times,
+// unless a newer result for the same object is sent in the meantime.
+func (c *Controller) processCheckResult(typ, name string, r health.Result) {
+	key := typ + "/" + name
+	c.sendCheckResult(typ, name, r, key, c.Results.next(key), 0)
+}
+
+func (c *Controller) sendCheckResult(typ, name string, r health.Result, key string, seq uint64, attempt int) {
+	exitStatus := int(r.State)
+	if typ == "Host" {
+		exitStatus = hostExitStatus(r.State)
+	}
+
+	log.Debugf("submitting check result for %s '%s': %d %s", typ, name, exitStatus, r.Output)
+
+	err := c.IcingaAPI.ProcessCheckResult(typ, name, exitStatus, r.Output, r.PerfData, c.PassiveTTL)
+	if err == nil {
+		c.Results.done(key, seq)
+		return
+	}
+
+	if IsIcingaNotFound(err) && attempt < CheckResultRetries {
+		log.Debugf("%s '%s' does not exist in icinga yet, retrying check result", typ, name)
+		time.AfterFunc(CheckResultRetryDelay<<uint(attempt), func() {
+			if c.Results.current(key, seq) {
+				c.sendCheckResult(typ, name, r, key, seq, attempt+1)
+			}
+		})
+		return
+	}
+
+	c.Results.done(key, seq)
+	log.Errorf("error submitting check result for %s '%s': %s", typ, name, err.Error())
+}
+
+// Numbers the check results sent to each Icinga object, so retries of outdated results can be dropped.
+type resultTracker struct {
+	sync.Mutex
+	latest map[string]uint64
+	seq    uint64
+}
+
+func (t *resultTracker) next(key string) uint64 {
+	t.Lock()
+	defer t.Unlock()
+
+	if t.latest == nil {
+		t.latest = make(map[string]uint64)
+	}
+	t.seq++
+	t.latest[key] = t.seq
+	return t.seq
+}
+
+// True if no newer result was sent for the object.
+func (t *resultTracker) current(key string, seq uint64) bool {
+	t.Lock()
+	defer t.Unlock()
+
+	return t.latest[key] == seq
+}
+
+func (t *resultTracker) done(key string, seq uint64) {
+	t.Lock()
+	defer t.Unlock()
+
+	if t.latest[key] == seq {
+		delete(t.latest, key)
+	}
+}
+
+// Hosts are only up or down; warnings are treated as up, like Icinga does with the result of active host checks.
+func hostExitStatus(state health.State) int {
+	if state == health.OK || state == health.Warning {
+		return 0
+	}
+	return 1
+}
+
+// Evaluate the health of an object. Monitored resources use their rule, services and ingresses need other objects.
+func (c *Controller) evaluate(o interface{}) health.Result {
+	switch o := o.(type) {
+	case *unstructured.Unstructured:
+		if o.GetAPIVersion() == "apiregistration.k8s.io/v1" && o.GetKind() == "APIService" {
+			return health.Unstructured("apiservice", o, health.Rule{Condition: "Available"})
+		}
+		if r := c.monitoredResourceByKind(o.GetAPIVersion(), o.GetKind()); r != nil {
+			return r.Evaluate(o)
+		}
+	case *corev1.Service:
+		ep, err := c.EndpointsLister.Endpoints(o.Namespace).Get(o.Name)
+		if err != nil && !errors.IsNotFound(err) {
+			return health.Result{State: health.Unknown, Output: fmt.Sprintf("error getting endpoints for service %s: %s", o.Name, err.Error())}
+		}
+		return health.Service(o, ep, c.LoadBalancerGrace)
+	case *admissionregistrationv1.ValidatingWebhookConfiguration:
+		var configs []admissionregistrationv1.WebhookClientConfig
+		for _, w := range o.Webhooks {
+			configs = append(configs, w.ClientConfig)
+		}
+		return health.ValidatingWebhookConfiguration(o, c.webhookEndpoints(configs...))
+	case *admissionregistrationv1.MutatingWebhookConfiguration:
+		var configs []admissionregistrationv1.WebhookClientConfig
+		for _, w := range o.Webhooks {
+			configs = append(configs, w.ClientConfig)
+		}
+		return health.MutatingWebhookConfiguration(o, c.webhookEndpoints(configs...))
+	case *corev1.Secret:
+		return health.TLSSecret(o, c.TLSWarningDays, c.TLSCriticalDays)
+	case *helmRelease:
+		if o.Err != nil {
+			return health.Result{State: health.Unknown, Output: fmt.Sprintf("helm release %s: %s", o.Name, o.Err.Error())}
+		}
+		return health.Helm(o.Release, c.HelmPendingGrace)
+	case *corev1.ResourceQuota:
+		warning, critical := c.usageThresholds(o, c.QuotaWarning, c.QuotaCritical)
+		return health.ResourceQuota(o, warning, critical)
+	case *corev1.PersistentVolumeClaim:
+		return health.PersistentVolumeClaim(o, c.PVCPendingGrace)
+	case *autoscalingv2beta2.HorizontalPodAutoscaler:
+		return health.HorizontalPodAutoscaler(o, c.HPAMaxReplicasGrace)
+	case *policyv1beta1.PodDisruptionBudget:
+		return health.PodDisruptionBudget(o, c.Disruptions.since(o), c.PDBBlockedGrace)
+	case *batchv1beta1.CronJob:
+		jobs, err := c.cronJobJobs(o)
+		if err != nil {
+			return health.Result{State: health.Unknown, Output: fmt.Sprintf("error listing jobs for cronjob %s: %s", o.Name, err.Error())}
+		}
+		return health.CronJob(o, jobs, c.CronJobWindowRuns)
+	case *networkingv1beta1.Ingress:
+		services, err := c.ServiceLister.Services(o.Namespace).List(labels.Everything())
+		if err != nil {
+			return health.Result{State: health.Unknown, Output: fmt.Sprintf("error listing services for ingress %s: %s", o.Name, err.Error())}
+		}
+		names := make(map[string]bool)
+		for _, s := range services {
+			names[s.Name] = true
+		}
+		return health.Ingress(o, names, c.LoadBalancerGrace)
+	}
+	return health.Evaluate(o)
+}
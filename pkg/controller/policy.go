@@ -0,0 +1,202 @@
+package main
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+
+	icingav1 "github.com/Nexinto/kubernetes-icinga/pkg/apis/icinga.nexinto.com/v1"
+)
+
+// Set up an informer for PodDisruptionBudgets, if the cluster serves policy/v1beta1. Kubernetes 1.25 removed it.
+func (c *Controller) InitializePodDisruptionBudgets() {
+	if !servesResource(c.Kubernetes.Discovery(), "policy/v1beta1", "poddisruptionbudgets") {
+		return
+	}
+
+	informer := c.KubernetesFactory.Policy().V1beta1().PodDisruptionBudgets()
+	c.PodDisruptionBudgetQueue = workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
+	c.PodDisruptionBudgetLister = informer.Lister()
+	c.PodDisruptionBudgetSynced = informer.Informer().HasSynced
+
+	enqueue(informer.Informer(), c.PodDisruptionBudgetQueue, func(obj interface{}) error {
+		o, ok := obj.(*policyv1beta1.PodDisruptionBudget)
+		if !ok {
+			return fmt.Errorf("object is not a PodDisruptionBudget %+v", obj)
+		}
+		return c.PodDisruptionBudgetDeleted(o)
+	})
+}
+
+func (c *Controller) RunPodDisruptionBudgets(stopCh <-chan struct{}) {
+	if c.PodDisruptionBudgetQueue == nil {
+		return
+	}
+	runQueue(c.KubernetesFactory, c.PodDisruptionBudgetSynced, c.PodDisruptionBudgetQueue, c.processPodDisruptionBudget, stopCh)
+}
+
+func (c *Controller) processPodDisruptionBudget(key string) error {
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		return fmt.Errorf("could not parse name %s: %s", key, err.Error())
+	}
+
+	o, err := c.PodDisruptionBudgetLister.PodDisruptionBudgets(namespace).Get(name)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return fmt.Errorf("tried to get %s, but it was not found", key)
+		} else {
+			return fmt.Errorf("error getting %s from cache: %s", key, err.Error())
+		}
+	}
+
+	return c.PodDisruptionBudgetCreatedOrUpdated(o)
+}
+
+// A PodDisruptionBudget that covers a single monitored workload is checked next to it. Otherwise, it is monitored
+// like workload itself.
+func (c *Controller) PodDisruptionBudgetCreatedOrUpdated(pdb *policyv1beta1.PodDisruptionBudget) error {
+	c.Disruptions.track(pdb)
+
+	target, abbrev := c.disruptionTarget(pdb)
+	if target == nil || !c.checkable("poddisruptionbudget") || !c.monitored(pdb) || pdb.GetDeletionTimestamp() != nil {
+		if err := c.deleteCheck(pdb.Namespace, "pdbcheck-"+pdb.Name); err != nil {
+			return err
+		}
+		return c.processWorkload(pdb, "pdb", "poddisruptionbudget", "PodDisruptionBudget", "policy/v1beta1")
+	}
+
+	if err := c.Mapping.UnmonitorWorkload(c, pdb, "pdb"); err != nil {
+		return err
+	}
+
+	host, name := c.Mapping.AttachedCheck(c, target, abbrev, "pdb-"+pdb.Name)
+
+	err := c.reconcileCheck(&icingav1.Check{
+		ObjectMeta: MakeObjectMeta(pdb, "PodDisruptionBudget", "policy/v1beta1", "pdbcheck", false),
+		Spec: icingav1.CheckSpec{
+			Name:         name,
+			Host:         host,
+			CheckCommand: c.CheckCommand(),
+			Vars:         c.MakeCheckVars(pdb, "poddisruptionbudget", true),
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	c.submitCheckResult("Service", c.Tag+"."+host+"!"+name, pdb)
+	return nil
+}
+
+func (c *Controller) PodDisruptionBudgetDeleted(pdb *policyv1beta1.PodDisruptionBudget) error {
+	log.Debugf("processing deleted poddisruptionbudget '%s/%s'", pdb.Namespace, pdb.Name)
+
+	c.Disruptions.forget(pdb)
+
+	if err := c.deleteCheck(pdb.Namespace, "pdbcheck-"+pdb.Name); err != nil {
+		return err
+	}
+	return c.Mapping.UnmonitorWorkload(c, pdb, "pdb")
+}
+
+// Remembers since when PodDisruptionBudgets allow no disruptions, by namespace/name. The API does not tell.
+type disruptionTracker struct {
+	sync.Mutex
+	blocked map[string]time.Time
+}
+
+func (t *disruptionTracker) track(pdb *policyv1beta1.PodDisruptionBudget) {
+	key := pdb.Namespace + "/" + pdb.Name
+
+	t.Lock()
+	defer t.Unlock()
+
+	if pdb.Status.PodDisruptionsAllowed > 0 {
+		delete(t.blocked, key)
+		return
+	}
+
+	if t.blocked == nil {
+		t.blocked = make(map[string]time.Time)
+	}
+	if _, ok := t.blocked[key]; !ok {
+		t.blocked[key] = time.Now()
+	}
+}
+
+func (t *disruptionTracker) forget(pdb *policyv1beta1.PodDisruptionBudget) {
+	t.Lock()
+	defer t.Unlock()
+
+	delete(t.blocked, pdb.Namespace+"/"+pdb.Name)
+}
+
+// Since when a PodDisruptionBudget allows no disruptions, zero if we don't know.
+func (t *disruptionTracker) since(pdb *policyv1beta1.PodDisruptionBudget) time.Time {
+	t.Lock()
+	defer t.Unlock()
+
+	return t.blocked[pdb.Namespace+"/"+pdb.Name]
+}
+
+// The monitored workload whose pods a PodDisruptionBudget selects and its abbreviation, nil if there is none or
+// more than one.
+func (c *Controller) disruptionTarget(pdb *policyv1beta1.PodDisruptionBudget) (metav1.Object, string) {
+	if pdb.Spec.Selector == nil {
+		return nil, ""
+	}
+
+	selector, err := metav1.LabelSelectorAsSelector(pdb.Spec.Selector)
+	if err != nil || selector.Empty() {
+		return nil, ""
+	}
+
+	var target metav1.Object
+	var abbrev string
+	found := 0
+
+	// All candidates are in the namespace of the PodDisruptionBudget, which is only looked up for the one we find.
+	match := func(o metav1.Object, a string, podLabels map[string]string) {
+		if selector.Matches(labels.Set(podLabels)) && monitoredObject(o) {
+			target, abbrev = o, a
+			found++
+		}
+	}
+
+	if deployments, err := c.DeploymentLister.Deployments(pdb.Namespace).List(labels.Everything()); err == nil {
+		for _, d := range deployments {
+			match(d, "deploy", d.Spec.Template.Labels)
+		}
+	}
+	if statefulsets, err := c.StatefulSetLister.StatefulSets(pdb.Namespace).List(labels.Everything()); err == nil {
+		for _, ss := range statefulsets {
+			match(ss, "statefulset", ss.Spec.Template.Labels)
+		}
+	}
+	if daemonsets, err := c.DaemonSetLister.DaemonSets(pdb.Namespace).List(labels.Everything()); err == nil {
+		for _, ds := range daemonsets {
+			match(ds, "ds", ds.Spec.Template.Labels)
+		}
+	}
+	if replicasets, err := c.ReplicaSetLister.ReplicaSets(pdb.Namespace).List(labels.Everything()); err == nil {
+		for _, rs := range replicasets {
+			match(rs, "rs", rs.Spec.Template.Labels)
+		}
+	}
+
+	if found != 1 || !c.monitored(target) {
+		return nil, ""
+	}
+
+	return target, abbrev
+}
@@ -0,0 +1,18 @@
+package main
+
+import (
+	log "github.com/sirupsen/logrus"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// ResourceQuotas are monitored like workload: as hosts in the hostgroup of their namespace or as checks on the host
+// of their namespace.
+func (c *Controller) ResourceQuotaCreatedOrUpdated(rq *corev1.ResourceQuota) error {
+	return c.processWorkload(rq, "quota", "resourcequota", "ResourceQuota", "v1")
+}
+
+func (c *Controller) ResourceQuotaDeleted(rq *corev1.ResourceQuota) error {
+	log.Debugf("processing deleted resourcequota '%s/%s'", rq.Namespace, rq.Name)
+	return c.Mapping.UnmonitorWorkload(c, rq, "quota")
+}
@@ -0,0 +1,255 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+
+	"github.com/Nexinto/kubernetes-icinga/pkg/health"
+)
+
+// With the annotation AnnReplicaChecks, every member of a StatefulSet gets a check of its own, attached to the
+// StatefulSet and named after its pod. The results are always sent as passive check results.
+func (c *Controller) processStatefulSetReplicas(ss *appsv1.StatefulSet) {
+	prefix := "replica-" + ss.Name + "-"
+	checks := make(map[string]health.Result)
+	pods := make(map[string]string)
+
+	if c.replicaChecked(ss) {
+		replicas := int32(1)
+		if ss.Spec.Replicas != nil {
+			replicas = *ss.Spec.Replicas
+		}
+
+		for i := int32(0); i < replicas; i++ {
+			ordinal := strconv.Itoa(int(i))
+			name := ss.Name + "-" + ordinal
+
+			pod, err := c.PodLister.Pods(ss.Namespace).Get(name)
+			if err != nil {
+				if !errors.IsNotFound(err) {
+					log.Errorf("error getting pod '%s/%s': %s", ss.Namespace, name, err.Error())
+					continue
+				}
+				pod = nil
+			} else if !metav1.IsControlledBy(pod, ss) {
+				pod = nil
+			}
+
+			checks[ordinal] = health.StatefulSetReplica(ss, name, pod)
+			pods[ordinal] = name
+		}
+	}
+
+	for ordinal, r := range checks {
+		meta := MakeObjectMeta(ss, "StatefulSet", "apps/v1", "", false)
+		meta.Name = prefix + ordinal
+		host, name := c.Mapping.AttachedCheck(c, ss, "statefulset", pods[ordinal])
+
+		if err := c.passiveCheck(meta, host, name, c.MakeVars(ss, "statefulset", true), r); err != nil {
+			log.Errorf("error creating check for pod '%s/%s': %s", ss.Namespace, pods[ordinal], err.Error())
+		}
+	}
+
+	c.pruneChecks(ss, prefix, checks)
+}
+
+// With the annotation AnnReplicaChecks, a DaemonSet gets a check for every node it should run on, attached to the
+// node. The results are always sent as passive check results.
+func (c *Controller) processDaemonSetNodes(ds *appsv1.DaemonSet) {
+	prefix := "dsnode-" + ds.Name + "-"
+	checks := make(map[string]health.Result)
+	nodes := make(map[string]*corev1.Node)
+
+	if c.replicaChecked(ds) {
+		all, err := c.NodeLister.List(labels.Everything())
+		if err != nil {
+			log.Errorf("error listing nodes: %s", err.Error())
+			return
+		}
+
+		selector, err := metav1.LabelSelectorAsSelector(ds.Spec.Selector)
+		if err != nil {
+			log.Errorf("invalid selector for daemonset '%s/%s': %s", ds.Namespace, ds.Name, err.Error())
+			return
+		}
+
+		pods, err := c.PodLister.Pods(ds.Namespace).List(selector)
+		if err != nil {
+			log.Errorf("error listing pods for daemonset '%s/%s': %s", ds.Namespace, ds.Name, err.Error())
+			return
+		}
+
+		byNode := make(map[string]*corev1.Pod)
+		for _, pod := range pods {
+			if metav1.IsControlledBy(pod, ds) && pod.Spec.NodeName != "" {
+				byNode[pod.Spec.NodeName] = pod
+			}
+		}
+
+		for _, node := range all {
+			if !c.monitored(node) || node.GetDeletionTimestamp() != nil || !daemonSetRunsOn(ds, node) {
+				continue
+			}
+			checks[node.Name] = health.DaemonSetPod(ds, node.Name, byNode[node.Name])
+			nodes[node.Name] = node
+		}
+	}
+
+	for label, r := range checks {
+		meta := MakeObjectMeta(ds, "DaemonSet", "apps/v1", "", false)
+		meta.Name = prefix + label
+		host, name := c.Mapping.NodeAttachedCheck(c, nodes[label], fmt.Sprintf("ds-%s-%s", ds.Namespace, ds.Name))
+
+		if err := c.passiveCheck(meta, host, name, c.MakeVars(ds, "daemonset", true), r); err != nil {
+			log.Errorf("error creating check for daemonset '%s/%s' on node '%s': %s", ds.Namespace, ds.Name, label, err.Error())
+		}
+	}
+
+	c.pruneChecks(ds, prefix, checks)
+}
+
+func (c *Controller) replicaChecked(o metav1.Object) bool {
+	a, ok := o.GetAnnotations()[AnnReplicaChecks]
+	return ok && a != "" && c.monitored(o) && o.GetDeletionTimestamp() == nil
+}
+
+// Whether a DaemonSet should run on a node: the node matches its node selector and its required node affinity, and
+// it tolerates the taints of the node. The taints the DaemonSet controller tolerates by itself are ignored.
+func daemonSetRunsOn(ds *appsv1.DaemonSet, node *corev1.Node) bool {
+	spec := ds.Spec.Template.Spec
+
+	if !labels.SelectorFromSet(spec.NodeSelector).Matches(labels.Set(node.Labels)) {
+		return false
+	}
+
+	if spec.Affinity != nil && spec.Affinity.NodeAffinity != nil {
+		if required := spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution; required != nil && !nodeSelectorMatches(required, node) {
+			return false
+		}
+	}
+
+	for i := range node.Spec.Taints {
+		taint := &node.Spec.Taints[i]
+		if taint.Effect == corev1.TaintEffectPreferNoSchedule || strings.HasPrefix(taint.Key, "node.kubernetes.io/") {
+			continue
+		}
+
+		tolerated := false
+		for j := range spec.Tolerations {
+			if spec.Tolerations[j].ToleratesTaint(taint) {
+				tolerated = true
+				break
+			}
+		}
+		if !tolerated {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Whether a node matches a node selector from a node affinity: any of its terms must match. An empty or unparseable
+// term matches nothing, like in the scheduler.
+func nodeSelectorMatches(selector *corev1.NodeSelector, node *corev1.Node) bool {
+	for _, term := range selector.NodeSelectorTerms {
+		if len(term.MatchExpressions) == 0 && len(term.MatchFields) == 0 {
+			continue
+		}
+		if nodeSelectorRequirementsMatch(term.MatchExpressions, labels.Set(node.Labels)) && nodeFieldsMatch(term.MatchFields, node) {
+			return true
+		}
+	}
+	return false
+}
+
+// Node names can be longer than label values, so the only supported field, metadata.name, is not matched as a label.
+func nodeFieldsMatch(requirements []corev1.NodeSelectorRequirement, node *corev1.Node) bool {
+	for _, r := range requirements {
+		if r.Key != "metadata.name" || len(r.Values) != 1 {
+			return false
+		}
+		switch r.Operator {
+		case corev1.NodeSelectorOpIn:
+			if r.Values[0] != node.Name {
+				return false
+			}
+		case corev1.NodeSelectorOpNotIn:
+			if r.Values[0] == node.Name {
+				return false
+			}
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+var nodeSelectorOperators = map[corev1.NodeSelectorOperator]selection.Operator{
+	corev1.NodeSelectorOpIn:           selection.In,
+	corev1.NodeSelectorOpNotIn:        selection.NotIn,
+	corev1.NodeSelectorOpExists:       selection.Exists,
+	corev1.NodeSelectorOpDoesNotExist: selection.DoesNotExist,
+	corev1.NodeSelectorOpGt:           selection.GreaterThan,
+	corev1.NodeSelectorOpLt:           selection.LessThan,
+}
+
+func nodeSelectorRequirementsMatch(requirements []corev1.NodeSelectorRequirement, set labels.Set) bool {
+	for _, r := range requirements {
+		op, ok := nodeSelectorOperators[r.Operator]
+		if !ok {
+			return false
+		}
+		requirement, err := labels.NewRequirement(r.Key, op, r.Values)
+		if err != nil || !requirement.Matches(set) {
+			return false
+		}
+	}
+	return true
+}
+
+// Delete the checks of owner whose names start with prefix, but are not in checks by the rest of their name. The
+// checks of cluster scoped owners are in kube-system.
+func (c *Controller) pruneChecks(owner metav1.Object, prefix string, checks map[string]health.Result) {
+	namespace := owner.GetNamespace()
+	if namespace == "" {
+		namespace = "kube-system"
+	}
+
+	existing, err := c.CheckLister.Checks(namespace).List(labels.Everything())
+	if err != nil {
+		log.Errorf("error listing checks: %s", err.Error())
+		return
+	}
+
+	for _, check := range existing {
+		if !strings.HasPrefix(check.Name, prefix) || !ownedBy(check, owner) {
+			continue
+		}
+		if _, ok := checks[strings.TrimPrefix(check.Name, prefix)]; ok {
+			continue
+		}
+		if err := c.deleteCheck(check.Namespace, check.Name); err != nil {
+			log.Errorf("error deleting check '%s/%s': %s", check.Namespace, check.Name, err.Error())
+		}
+	}
+}
+
+func ownedBy(o, owner metav1.Object) bool {
+	for _, ref := range o.GetOwnerReferences() {
+		if ref.UID == owner.GetUID() {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,213 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"gopkg.in/yaml.v2"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic/dynamicinformer"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+
+	"github.com/Nexinto/kubernetes-icinga/pkg/health"
+)
+
+// A kind of resource that is not built in, but monitored through a dynamic informer. The health of the objects
+// is determined by a condition or by the value of a field.
+type MonitoredResource struct {
+	Group    string `yaml:"group"`
+	Version  string `yaml:"version"`
+	Resource string `yaml:"resource"`
+	Kind     string `yaml:"kind"`
+
+	// Used for VarType and the check endpoint; defaults to the lowercase kind.
+	Type string `yaml:"type"`
+
+	// Prefix for the Icinga object names; defaults to the type.
+	Abbrev string `yaml:"abbrev"`
+
+	Condition string   `yaml:"condition"`
+	Path      string   `yaml:"path"`
+	Healthy   []string `yaml:"healthy"`
+	Warning   []string `yaml:"warning"`
+
+	queue  workqueue.RateLimitingInterface
+	lister cache.GenericLister
+	synced cache.InformerSynced
+}
+
+// Parse a YAML list of monitored resources.
+func ParseMonitoredResources(data string) ([]*MonitoredResource, error) {
+	var resources []*MonitoredResource
+
+	if err := yaml.Unmarshal([]byte(data), &resources); err != nil {
+		return nil, err
+	}
+
+	for _, r := range resources {
+		if r.Version == "" || r.Resource == "" || r.Kind == "" {
+			return nil, fmt.Errorf("version, resource and kind are required for monitored resources ('%s')", r.GroupVersionResource())
+		}
+		if r.Condition != "" && r.Path != "" {
+			return nil, fmt.Errorf("monitored resource '%s' can have a condition or a path, not both", r.GroupVersionResource())
+		}
+		if r.Type == "" {
+			r.Type = strings.ToLower(r.Kind)
+		}
+		if r.Abbrev == "" {
+			r.Abbrev = r.Type
+		}
+	}
+
+	return resources, nil
+}
+
+func (r *MonitoredResource) GroupVersionResource() schema.GroupVersionResource {
+	return schema.GroupVersionResource{Group: r.Group, Version: r.Version, Resource: r.Resource}
+}
+
+func (r *MonitoredResource) APIVersion() string {
+	return schema.GroupVersion{Group: r.Group, Version: r.Version}.String()
+}
+
+func (r *MonitoredResource) Evaluate(o *unstructured.Unstructured) health.Result {
+	return health.Unstructured(r.Type, o, health.Rule{
+		Condition: r.Condition,
+		Path:      r.Path,
+		Healthy:   r.Healthy,
+		Warning:   r.Warning,
+	})
+}
+
+// Set up informers for the monitored resources. Expects c.Dynamic to be set. Resources that the cluster
+// does not serve are not monitored.
+func (c *Controller) InitializeMonitoredResources() {
+	if len(c.MonitoredResources) == 0 {
+		return
+	}
+
+	if c.Dynamic == nil {
+		panic("c.Dynamic is nil")
+	}
+
+	c.DynamicFactory = dynamicinformer.NewDynamicSharedInformerFactory(c.Dynamic, time.Second*60)
+
+	var resources []*MonitoredResource
+
+	for _, r := range c.MonitoredResources {
+		if err := c.checkMonitoredResource(r); err != nil {
+			log.Errorf("not monitoring %s: %s", r.GroupVersionResource(), err.Error())
+			continue
+		}
+
+		r := r
+		informer := c.DynamicFactory.ForResource(r.GroupVersionResource())
+		r.queue = workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
+		r.lister = informer.Lister()
+		r.synced = informer.Informer().HasSynced
+
+		enqueue(informer.Informer(), r.queue, func(obj interface{}) error {
+			o, ok := obj.(*unstructured.Unstructured)
+			if !ok {
+				return fmt.Errorf("object is not a %s %+v", r.Kind, obj)
+			}
+			return c.MonitoredResourceDeleted(r, o)
+		})
+
+		resources = append(resources, r)
+	}
+
+	c.MonitoredResources = resources
+}
+
+// Only namespaced resources can be monitored, as they are mapped like workload.
+func (c *Controller) checkMonitoredResource(r *MonitoredResource) error {
+	list, err := c.Kubernetes.Discovery().ServerResourcesForGroupVersion(r.APIVersion())
+	if err != nil && !errors.IsNotFound(err) {
+		return fmt.Errorf("error discovering resources for %s: %s", r.APIVersion(), err.Error())
+	}
+
+	if list != nil {
+		for _, a := range list.APIResources {
+			if a.Name != r.Resource {
+				continue
+			}
+			if !a.Namespaced {
+				return fmt.Errorf("the resource is not namespaced")
+			}
+			if a.Kind != r.Kind {
+				return fmt.Errorf("the resource has kind '%s', not '%s'", a.Kind, r.Kind)
+			}
+			return nil
+		}
+	}
+
+	return fmt.Errorf("the cluster does not serve the resource")
+}
+
+func (c *Controller) RunMonitoredResources(stopCh <-chan struct{}) {
+	for _, r := range c.MonitoredResources {
+		r := r
+		go runQueue(c.DynamicFactory, r.synced, r.queue, func(key string) error {
+			return c.processMonitoredResource(r, key)
+		}, stopCh)
+	}
+}
+
+func (c *Controller) processMonitoredResource(r *MonitoredResource, key string) error {
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		return fmt.Errorf("could not parse name %s: %s", key, err.Error())
+	}
+
+	o, err := r.lister.ByNamespace(namespace).Get(name)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return fmt.Errorf("tried to get %s, but it was not found", key)
+		} else {
+			return fmt.Errorf("error getting %s from cache: %s", key, err.Error())
+		}
+	}
+
+	u, ok := o.(*unstructured.Unstructured)
+	if !ok {
+		return fmt.Errorf("%s is not unstructured", key)
+	}
+
+	return c.MonitoredResourceCreatedOrUpdated(r, u)
+}
+
+func (c *Controller) MonitoredResourceCreatedOrUpdated(r *MonitoredResource, o *unstructured.Unstructured) error {
+	return c.processWorkload(o, r.Abbrev, r.Type, r.Kind, r.APIVersion())
+}
+
+func (c *Controller) MonitoredResourceDeleted(r *MonitoredResource, o *unstructured.Unstructured) error {
+	log.Debugf("processing deleted %s '%s/%s'", r.Type, o.GetNamespace(), o.GetName())
+	return c.Mapping.UnmonitorWorkload(c, o, r.Abbrev)
+}
+
+// The monitored resource for objects with this apiVersion and kind, nil if there is none.
+func (c *Controller) monitoredResourceByKind(apiVersion, kind string) *MonitoredResource {
+	for _, r := range c.MonitoredResources {
+		if r.APIVersion() == apiVersion && r.Kind == kind {
+			return r
+		}
+	}
+	return nil
+}
+
+// The monitored resource with this type, nil if there is none.
+func (c *Controller) monitoredResourceByType(typ string) *MonitoredResource {
+	for _, r := range c.MonitoredResources {
+		if r.Type == typ {
+			return r
+		}
+	}
+	return nil
+}
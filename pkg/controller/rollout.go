@@ -0,0 +1,114 @@
+package main
+
+import (
+	"strconv"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/Nexinto/kubernetes-icinga/pkg/health"
+)
+
+// With RolloutChecks, Deployments, StatefulSets and DaemonSets get a check named rollout, attached to the workload.
+// The check created by MonitorWorkload stays OK while a rollout hangs, as the old pods are still available. The
+// results are always sent as passive check results. rollout is only called if the check is needed.
+func (c *Controller) processRollout(o metav1.Object, abbrev, typ, kind string, rollout func() health.Rollout) {
+	meta := MakeObjectMeta(o, kind, "apps/v1", "rollout-"+abbrev, false)
+
+	if !c.RolloutChecks || !c.monitored(o) || o.GetDeletionTimestamp() != nil {
+		c.rolloutDeleted(o, abbrev, kind)
+		return
+	}
+
+	ro := rollout()
+	since := c.Rollouts.track(kind, o, ro.InProgress)
+	host, name := c.Mapping.AttachedCheck(c, o, abbrev, "rollout")
+
+	r := health.RolloutProgress(ro, since, c.RolloutGrace)
+	if err := c.passiveCheck(meta, host, name, c.MakeVars(o, typ, true), r); err != nil {
+		log.Errorf("error creating rollout check for %s '%s/%s': %s", typ, o.GetNamespace(), o.GetName(), err.Error())
+	}
+}
+
+// Delete the rollout check of a workload, if it has one.
+func (c *Controller) rolloutDeleted(o metav1.Object, abbrev, kind string) {
+	c.Rollouts.forget(kind, o)
+
+	name := "rollout-" + abbrev + "-" + o.GetName()
+	if _, err := c.CheckLister.Checks(o.GetNamespace()).Get(name); err != nil {
+		return
+	}
+	if err := c.deleteCheck(o.GetNamespace(), name); err != nil {
+		log.Errorf("error deleting rollout check for '%s/%s': %s", o.GetNamespace(), o.GetName(), err.Error())
+	}
+}
+
+// The revision of the oldest ReplicaSet of a Deployment that still has pods, the revision of the Deployment if
+// there is none.
+func (c *Controller) deploymentCurrentRevision(d *appsv1.Deployment) string {
+	current := d.Annotations["deployment.kubernetes.io/revision"]
+
+	replicasets, err := c.ReplicaSetLister.ReplicaSets(d.Namespace).List(labels.Everything())
+	if err != nil {
+		log.Errorf("error listing replicasets for deployment '%s/%s': %s", d.Namespace, d.Name, err.Error())
+		return current
+	}
+
+	oldest := -1
+	for _, rs := range replicasets {
+		if !metav1.IsControlledBy(rs, d) || rs.Status.Replicas == 0 {
+			continue
+		}
+		revision, err := strconv.Atoi(rs.Annotations["deployment.kubernetes.io/revision"])
+		if err != nil {
+			continue
+		}
+		if oldest < 0 || revision < oldest {
+			oldest = revision
+		}
+	}
+
+	if oldest < 0 {
+		return current
+	}
+	return strconv.Itoa(oldest)
+}
+
+// Remembers since when rollouts are in progress, by kind/namespace/name. The status of a workload does not tell.
+type rolloutTracker struct {
+	sync.Mutex
+	started map[string]time.Time
+}
+
+// Record whether a rollout is in progress, and return since when it is, zero if it is not.
+func (t *rolloutTracker) track(kind string, o metav1.Object, inProgress bool) time.Time {
+	key := kind + "/" + o.GetNamespace() + "/" + o.GetName()
+
+	t.Lock()
+	defer t.Unlock()
+
+	if !inProgress {
+		delete(t.started, key)
+		return time.Time{}
+	}
+
+	if t.started == nil {
+		t.started = make(map[string]time.Time)
+	}
+	if _, ok := t.started[key]; !ok {
+		t.started[key] = time.Now()
+	}
+	return t.started[key]
+}
+
+func (t *rolloutTracker) forget(kind string, o metav1.Object) {
+	t.Lock()
+	defer t.Unlock()
+
+	delete(t.started, kind+"/"+o.GetNamespace()+"/"+o.GetName())
+}
@@ -0,0 +1,134 @@
+package main
+
+import (
+	"reflect"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+
+	icingav1 "github.com/Nexinto/kubernetes-icinga/pkg/apis/icinga.nexinto.com/v1"
+)
+
+var (
+	hostStateNames    = []string{"UP", "DOWN"}
+	serviceStateNames = []string{"OK", "WARNING", "CRITICAL", "UNKNOWN"}
+	stateTypeNames    = []string{"SOFT", "HARD"}
+)
+
+// Mirror the state of our Icinga hosts and services into the status of the custom resources.
+func (c *Controller) RefreshIcingaStates() {
+	for {
+		c.refreshIcingaStates()
+		time.Sleep(60 * time.Second)
+	}
+}
+
+func (c *Controller) refreshIcingaStates() {
+	hosts, err := c.IcingaAPI.ListHostStates(c.Tag)
+	if err != nil {
+		log.Errorf("error getting host states from icinga: %s", err.Error())
+	} else {
+		for _, state := range hosts {
+			c.updateHostCheckResult(state)
+		}
+	}
+
+	services, err := c.IcingaAPI.ListServiceStates(c.Tag)
+	if err != nil {
+		log.Errorf("error getting service states from icinga: %s", err.Error())
+	} else {
+		for _, state := range services {
+			c.updateCheckCheckResult(state)
+		}
+	}
+}
+
+func (c *Controller) updateHostCheckResult(state IcingaState) {
+	if state.Owner == "" {
+		return
+	}
+	namespace, name, err := cache.SplitMetaNamespaceKey(state.Owner)
+	if err != nil {
+		log.Errorf("error parsing owner of host '%s' ('%s'): %s", state.Name, state.Owner, err.Error())
+		return
+	}
+
+	host, err := c.HostLister.Hosts(namespace).Get(name)
+	if err != nil {
+		if !errors.IsNotFound(err) {
+			log.Errorf("error getting host cr '%s/%s': %s", namespace, name, err.Error())
+		}
+		return
+	}
+
+	result := makeCheckResult(state, hostStateNames)
+	if reflect.DeepEqual(host.Status.CheckResult, result) {
+		return
+	}
+
+	h := host.DeepCopy()
+	h.Status.CheckResult = result
+	if _, err := c.IcingaClient.IcingaV1().Hosts(h.Namespace).UpdateStatus(h); err != nil {
+		log.Errorf("error updating status of host cr '%s/%s': %s", h.Namespace, h.Name, err.Error())
+	}
+}
+
+func (c *Controller) updateCheckCheckResult(state IcingaState) {
+	if state.Owner == "" {
+		return
+	}
+	namespace, name, err := cache.SplitMetaNamespaceKey(state.Owner)
+	if err != nil {
+		log.Errorf("error parsing owner of service '%s' ('%s'): %s", state.Name, state.Owner, err.Error())
+		return
+	}
+
+	check, err := c.CheckLister.Checks(namespace).Get(name)
+	if err != nil {
+		if !errors.IsNotFound(err) {
+			log.Errorf("error getting check cr '%s/%s': %s", namespace, name, err.Error())
+		}
+		return
+	}
+
+	result := makeCheckResult(state, serviceStateNames)
+	if reflect.DeepEqual(check.Status.CheckResult, result) {
+		return
+	}
+
+	ch := check.DeepCopy()
+	ch.Status.CheckResult = result
+	if _, err := c.IcingaClient.IcingaV1().Checks(ch.Namespace).UpdateStatus(ch); err != nil {
+		log.Errorf("error updating status of check cr '%s/%s': %s", ch.Namespace, ch.Name, err.Error())
+	}
+}
+
+func makeCheckResult(state IcingaState, stateNames []string) *icingav1.CheckResult {
+	result := &icingav1.CheckResult{
+		State:     "PENDING",
+		StateType: stateName(state.StateType, stateTypeNames),
+	}
+
+	if state.Checked {
+		result.State = stateName(state.State, stateNames)
+		result.Output = state.Output
+	}
+
+	if !state.LastStateChange.IsZero() {
+		t := metav1.NewTime(state.LastStateChange)
+		result.LastStateChange = &t
+	}
+
+	return result
+}
+
+func stateName(state int, names []string) string {
+	if state < 0 || state >= len(names) {
+		return "UNKNOWN"
+	}
+	return names[state]
+}
@@ -0,0 +1,131 @@
+package main
+
+import (
+	"fmt"
+	"reflect"
+
+	log "github.com/sirupsen/logrus"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	icingav1 "github.com/Nexinto/kubernetes-icinga/pkg/apis/icinga.nexinto.com/v1"
+)
+
+// Returned when an Icinga object with the name we want to use exists, but is not tagged with our cluster.
+type NotManagedError struct {
+	Kind  string
+	Name  string
+	Owner interface{}
+}
+
+func (e *NotManagedError) Error() string {
+	return fmt.Sprintf("cannot update %s '%s': it is not managed by us ('%s')", e.Kind, e.Name, e.Owner)
+}
+
+// Compute the status of a custom resource after an attempt to sync it to Icinga.
+// written is true if the Icinga object was actually created or updated.
+func makeSyncStatus(old icingav1.SyncStatus, generation int64, icingaName string, written bool, err error) icingav1.SyncStatus {
+	status := *old.DeepCopy()
+	status.IcingaName = icingaName
+	status.ObservedGeneration = generation
+
+	if err == nil {
+		status.LastError = ""
+		status.Conditions = setCondition(status.Conditions, icingav1.ConditionSynced, corev1.ConditionTrue, "Synced", "")
+		status.Conditions = setCondition(status.Conditions, icingav1.ConditionIcingaRejected, corev1.ConditionFalse, "", "")
+		status.Conditions = setCondition(status.Conditions, icingav1.ConditionNotManagedByUs, corev1.ConditionFalse, "", "")
+		if written || status.LastSyncTime == nil {
+			now := metav1.Now()
+			status.LastSyncTime = &now
+		}
+		return status
+	}
+
+	status.LastError = err.Error()
+
+	if _, ok := err.(*NotManagedError); ok {
+		status.Conditions = setCondition(status.Conditions, icingav1.ConditionSynced, corev1.ConditionFalse, string(icingav1.ConditionNotManagedByUs), err.Error())
+		status.Conditions = setCondition(status.Conditions, icingav1.ConditionIcingaRejected, corev1.ConditionFalse, "", "")
+		status.Conditions = setCondition(status.Conditions, icingav1.ConditionNotManagedByUs, corev1.ConditionTrue, string(icingav1.ConditionNotManagedByUs), err.Error())
+	} else {
+		status.Conditions = setCondition(status.Conditions, icingav1.ConditionSynced, corev1.ConditionFalse, string(icingav1.ConditionIcingaRejected), err.Error())
+		status.Conditions = setCondition(status.Conditions, icingav1.ConditionIcingaRejected, corev1.ConditionTrue, string(icingav1.ConditionIcingaRejected), err.Error())
+		status.Conditions = setCondition(status.Conditions, icingav1.ConditionNotManagedByUs, corev1.ConditionFalse, "", "")
+	}
+
+	return status
+}
+
+// Set a condition, keeping the transition time if the status did not change.
+func setCondition(conditions []icingav1.Condition, typ icingav1.ConditionType, status corev1.ConditionStatus, reason, message string) []icingav1.Condition {
+	c := icingav1.Condition{
+		Type:               typ,
+		Status:             status,
+		Reason:             reason,
+		Message:            message,
+		LastTransitionTime: metav1.Now(),
+	}
+
+	for i := range conditions {
+		if conditions[i].Type != typ {
+			continue
+		}
+		if conditions[i].Status == status {
+			c.LastTransitionTime = conditions[i].LastTransitionTime
+		}
+		conditions[i] = c
+		return conditions
+	}
+
+	return append(conditions, c)
+}
+
+// Get a condition from a status, nil if it is not set.
+func getCondition(status icingav1.SyncStatus, typ icingav1.ConditionType) *icingav1.Condition {
+	for i := range status.Conditions {
+		if status.Conditions[i].Type == typ {
+			return &status.Conditions[i]
+		}
+	}
+	return nil
+}
+
+func (c *Controller) updateHostGroupStatus(hostgroup *icingav1.HostGroup, icingaName string, written bool, err error) {
+	status := makeSyncStatus(hostgroup.Status.SyncStatus, hostgroup.Generation, icingaName, written, err)
+	if reflect.DeepEqual(status, hostgroup.Status.SyncStatus) {
+		return
+	}
+
+	hg := hostgroup.DeepCopy()
+	hg.Status.SyncStatus = status
+	if _, err := c.IcingaClient.IcingaV1().HostGroups(hg.Namespace).UpdateStatus(hg); err != nil {
+		log.Errorf("error updating status of hostgroup cr '%s/%s': %s", hg.Namespace, hg.Name, err.Error())
+	}
+}
+
+func (c *Controller) updateHostStatus(host *icingav1.Host, icingaName string, written bool, err error) {
+	status := makeSyncStatus(host.Status.SyncStatus, host.Generation, icingaName, written, err)
+	if reflect.DeepEqual(status, host.Status.SyncStatus) {
+		return
+	}
+
+	h := host.DeepCopy()
+	h.Status.SyncStatus = status
+	if _, err := c.IcingaClient.IcingaV1().Hosts(h.Namespace).UpdateStatus(h); err != nil {
+		log.Errorf("error updating status of host cr '%s/%s': %s", h.Namespace, h.Name, err.Error())
+	}
+}
+
+func (c *Controller) updateCheckStatus(check *icingav1.Check, icingaName string, written bool, err error) {
+	status := makeSyncStatus(check.Status.SyncStatus, check.Generation, icingaName, written, err)
+	if reflect.DeepEqual(status, check.Status.SyncStatus) {
+		return
+	}
+
+	ch := check.DeepCopy()
+	ch.Status.SyncStatus = status
+	if _, err := c.IcingaClient.IcingaV1().Checks(ch.Namespace).UpdateStatus(ch); err != nil {
+		log.Errorf("error updating status of check cr '%s/%s': %s", ch.Namespace, ch.Name, err.Error())
+	}
+}
@@ -0,0 +1,36 @@
+package main
+
+import (
+	log "github.com/sirupsen/logrus"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+func (c *Controller) PersistentVolumeClaimCreatedOrUpdated(pvc *corev1.PersistentVolumeClaim) error {
+	return c.processWorkload(pvc, "pvc", "persistentvolumeclaim", "PersistentVolumeClaim", "v1")
+}
+
+func (c *Controller) PersistentVolumeClaimDeleted(pvc *corev1.PersistentVolumeClaim) error {
+	log.Debugf("processing deleted persistentvolumeclaim '%s/%s'", pvc.Namespace, pvc.Name)
+	return c.Mapping.UnmonitorWorkload(c, pvc, "pvc")
+}
+
+// PersistentVolumes are not namespaced and monitored as infrastructure.
+func (c *Controller) PersistentVolumeCreatedOrUpdated(pv *corev1.PersistentVolume) error {
+	log.Debugf("processing persistentvolume '%s'", pv.Name)
+	if !c.monitored(pv) || !c.checkable("persistentvolume") || pv.GetDeletionTimestamp() != nil {
+		return c.Mapping.UnmonitorPersistentVolume(c, pv)
+	}
+
+	if err := c.Mapping.MonitorPersistentVolume(c, pv); err != nil {
+		return err
+	}
+	typ, name := c.Mapping.PersistentVolumeCheckable(c, pv)
+	c.submitCheckResult(typ, name, pv)
+	return nil
+}
+
+func (c *Controller) PersistentVolumeDeleted(pv *corev1.PersistentVolume) error {
+	log.Debugf("processing deleted persistentvolume '%s'", pv.Name)
+	return c.Mapping.UnmonitorPersistentVolume(c, pv)
+}
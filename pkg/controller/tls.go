@@ -0,0 +1,86 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	kubernetesinformers "k8s.io/client-go/informers"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+)
+
+// Set up an informer for TLS Secrets. It has its own factory, so only Secrets of type kubernetes.io/tls are cached.
+func (c *Controller) InitializeTLSSecrets() {
+	c.SecretFactory = kubernetesinformers.NewSharedInformerFactoryWithOptions(c.Kubernetes, time.Second*60,
+		kubernetesinformers.WithTweakListOptions(func(options *metav1.ListOptions) {
+			options.FieldSelector = fields.OneTermEqualSelector("type", string(corev1.SecretTypeTLS)).String()
+		}))
+
+	informer := c.SecretFactory.Core().V1().Secrets()
+	c.SecretQueue = workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
+	c.SecretLister = informer.Lister()
+	c.SecretSynced = informer.Informer().HasSynced
+
+	enqueue(informer.Informer(), c.SecretQueue, func(obj interface{}) error {
+		o, ok := obj.(*corev1.Secret)
+		if !ok {
+			return fmt.Errorf("object is not a Secret %+v", obj)
+		}
+		return c.SecretDeleted(o)
+	})
+}
+
+func (c *Controller) RunTLSSecrets(stopCh <-chan struct{}) {
+	runQueue(c.SecretFactory, c.SecretSynced, c.SecretQueue, c.processSecret, stopCh)
+}
+
+func (c *Controller) processSecret(key string) error {
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		return fmt.Errorf("could not parse name %s: %s", key, err.Error())
+	}
+
+	o, err := c.SecretLister.Secrets(namespace).Get(name)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return fmt.Errorf("tried to get %s, but it was not found", key)
+		} else {
+			return fmt.Errorf("error getting %s from cache: %s", key, err.Error())
+		}
+	}
+
+	return c.SecretCreatedOrUpdated(o)
+}
+
+// TLS Secrets are checked if they or their namespace have the annotation AnnTLSCheck.
+func (c *Controller) SecretCreatedOrUpdated(secret *corev1.Secret) error {
+	if secret.Type != corev1.SecretTypeTLS || !c.tlsChecked(secret) {
+		return c.Mapping.UnmonitorWorkload(c, secret, "tls")
+	}
+	return c.processWorkload(secret, "tls", "secret", "Secret", "v1")
+}
+
+func (c *Controller) SecretDeleted(secret *corev1.Secret) error {
+	log.Debugf("processing deleted secret '%s/%s'", secret.Namespace, secret.Name)
+	return c.Mapping.UnmonitorWorkload(c, secret, "tls")
+}
+
+func (c *Controller) tlsChecked(secret *corev1.Secret) bool {
+	if a, ok := secret.GetAnnotations()[AnnTLSCheck]; ok && a != "" {
+		return true
+	}
+
+	namespace, err := c.NamespaceLister.Get(secret.Namespace)
+	if err != nil {
+		return false
+	}
+
+	a, ok := namespace.GetAnnotations()[AnnTLSCheck]
+	return ok && a != ""
+}
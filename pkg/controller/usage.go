@@ -0,0 +1,219 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	icingav1 "github.com/Nexinto/kubernetes-icinga/pkg/apis/icinga.nexinto.com/v1"
+	"github.com/Nexinto/kubernetes-icinga/pkg/health"
+)
+
+// The parts of the kubelet stats summary we use.
+type statsSummary struct {
+	Node struct {
+		Memory *memoryStats `json:"memory"`
+		Fs     *fsStats     `json:"fs"`
+	} `json:"node"`
+	Pods []struct {
+		VolumeStats []volumeStats `json:"volume"`
+	} `json:"pods"`
+}
+
+type memoryStats struct {
+	WorkingSetBytes *uint64 `json:"workingSetBytes"`
+}
+
+type fsStats struct {
+	AvailableBytes *uint64 `json:"availableBytes"`
+	CapacityBytes  *uint64 `json:"capacityBytes"`
+}
+
+// The bytes used on a filesystem. Some network and fuse filesystems report more available bytes than their capacity.
+func (fs *fsStats) used() uint64 {
+	if *fs.AvailableBytes > *fs.CapacityBytes {
+		return 0
+	}
+	return *fs.CapacityBytes - *fs.AvailableBytes
+}
+
+type volumeStats struct {
+	fsStats
+	PVCRef *struct {
+		Name      string `json:"name"`
+		Namespace string `json:"namespace"`
+	} `json:"pvcRef"`
+}
+
+// Check filesystem and memory usage of the nodes and the usage of mounted PersistentVolumeClaims. The results are
+// always sent as passive check results.
+func (c *Controller) RefreshUsage() {
+	for {
+		nodes, err := c.NodeLister.List(labels.Everything())
+		if err != nil {
+			log.Errorf("error listing nodes: %s", err.Error())
+		} else {
+			for _, node := range nodes {
+				summary, err := c.statsSummary(node.Name)
+				if err != nil {
+					log.Errorf("error getting stats summary for node '%s': %s", node.Name, err.Error())
+					continue
+				}
+				c.processUsage(node, summary)
+			}
+		}
+
+		time.Sleep(60 * time.Second)
+	}
+}
+
+// Get the stats summary from the kubelet through the API server node proxy.
+func (c *Controller) statsSummary(node string) (*statsSummary, error) {
+	data, err := c.Kubernetes.CoreV1().RESTClient().Get().
+		Resource("nodes").Name(node).SubResource("proxy").Suffix("stats", "summary").DoRaw()
+	if err != nil {
+		return nil, err
+	}
+
+	var summary statsSummary
+	if err := json.Unmarshal(data, &summary); err != nil {
+		return nil, fmt.Errorf("error parsing stats summary: %s", err.Error())
+	}
+
+	return &summary, nil
+}
+
+func (c *Controller) processUsage(node *corev1.Node, summary *statsSummary) {
+	if !c.monitored(node) || node.GetDeletionTimestamp() != nil {
+		for _, label := range []string{"filesystem", "memory"} {
+			if err := c.deleteCachedCheck("kube-system", node.Name+"-usage-"+label); err != nil {
+				log.Errorf("error deleting usage check for node '%s': %s", node.Name, err.Error())
+			}
+		}
+	} else {
+		warning, critical := c.usageThresholds(node, c.UsageWarning, c.UsageCritical)
+
+		if fs := summary.Node.Fs; fs != nil && fs.CapacityBytes != nil && fs.AvailableBytes != nil {
+			r := health.Usage("filesystem", fs.used(), *fs.CapacityBytes, warning, critical)
+			c.nodeUsage(node, "filesystem", r)
+		}
+
+		if memory := summary.Node.Memory; memory != nil && memory.WorkingSetBytes != nil {
+			capacity := node.Status.Capacity.Memory().Value()
+			r := health.Usage("memory", *memory.WorkingSetBytes, uint64(capacity), warning, critical)
+			c.nodeUsage(node, "memory", r)
+		}
+	}
+
+	// The namespaces whose host for namespace checks we made sure of.
+	namespaces := make(map[string]bool)
+
+	for _, pod := range summary.Pods {
+		for _, volume := range pod.VolumeStats {
+			if volume.PVCRef == nil || volume.CapacityBytes == nil || volume.AvailableBytes == nil {
+				continue
+			}
+
+			pvc, err := c.PersistentVolumeClaimLister.PersistentVolumeClaims(volume.PVCRef.Namespace).Get(volume.PVCRef.Name)
+			if err != nil {
+				continue
+			}
+
+			if !c.monitored(pvc) || pvc.GetDeletionTimestamp() != nil {
+				if err := c.deleteCachedCheck(pvc.Namespace, "pvc-"+pvc.Name+"-usage"); err != nil {
+					log.Errorf("error deleting usage check for persistentvolumeclaim '%s/%s': %s", pvc.Namespace, pvc.Name, err.Error())
+				}
+				continue
+			}
+
+			// The check is attached to the claim. Where claims are not monitored themselves because check_kubernetes
+			// cannot check them, it is attached to the namespace instead.
+			host, name := c.Mapping.AttachedCheck(c, pvc, "pvc", "usage")
+			if !c.checkable("persistentvolumeclaim") {
+				namespace, err := c.NamespaceLister.Get(pvc.Namespace)
+				if err != nil {
+					log.Errorf("error getting namespace '%s' from cache: %s", pvc.Namespace, err.Error())
+					continue
+				}
+				if !namespaces[namespace.Name] {
+					if err := c.Mapping.MonitorNamespaceChecks(c, namespace); err != nil {
+						log.Errorf("error creating host for checks of namespace '%s': %s", namespace.Name, err.Error())
+						continue
+					}
+					namespaces[namespace.Name] = true
+				}
+				host, name = c.Mapping.NamespaceCheck(c, namespace, "pvc-"+pvc.Name+"-usage")
+			}
+
+			warning, critical := c.usageThresholds(pvc, c.UsageWarning, c.UsageCritical)
+			r := health.Usage("filesystem", volume.used(), *volume.CapacityBytes, warning, critical)
+
+			meta := MakeObjectMeta(pvc, "PersistentVolumeClaim", "v1", "pvc", false)
+			meta.Name += "-usage"
+
+			if err := c.passiveCheck(meta, host, name, c.MakeVars(pvc, "persistentvolumeclaim", true), r); err != nil {
+				log.Errorf("error creating usage check for persistentvolumeclaim '%s/%s': %s", pvc.Namespace, pvc.Name, err.Error())
+			}
+		}
+	}
+}
+
+func (c *Controller) nodeUsage(node *corev1.Node, label string, r health.Result) {
+	meta := MakeObjectMeta(node, "Node", "v1", "", true)
+	meta.Name += "-usage-" + label
+	host, name := c.Mapping.NodeAttachedCheck(c, node, label)
+
+	if err := c.passiveCheck(meta, host, name, c.MakeVars(node, "node", false), r); err != nil {
+		log.Errorf("error creating %s usage check for node '%s': %s", label, node.Name, err.Error())
+	}
+}
+
+// Create a passive check and send its result.
+func (c *Controller) passiveCheck(meta metav1.ObjectMeta, host, name string, vars map[string]string, r health.Result) error {
+	err := c.reconcileCheck(&icingav1.Check{
+		ObjectMeta: meta,
+		Spec: icingav1.CheckSpec{
+			Name:         name,
+			Host:         host,
+			CheckCommand: "passive",
+			Vars:         vars,
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	c.processCheckResult("Service", c.Tag+"."+host+"!"+name, r)
+	return nil
+}
+
+// The warning and critical usage thresholds for an object, from its annotations or the defaults.
+func (c *Controller) usageThresholds(o metav1.Object, warning, critical int) (int, int) {
+	return usageThreshold(o, AnnUsageWarning, warning), usageThreshold(o, AnnUsageCritical, critical)
+}
+
+// A threshold in percent from an annotation, def if the annotation is not set or not a percentage.
+func usageThreshold(o metav1.Object, annotation string, def int) int {
+	a, ok := o.GetAnnotations()[annotation]
+	if !ok {
+		return def
+	}
+
+	v, err := strconv.Atoi(a)
+	if err == nil && (v < 0 || v > 100) {
+		err = fmt.Errorf("%d is not between 0 and 100", v)
+	}
+	if err != nil {
+		log.Warnf("ignoring invalid annotation %s on '%s/%s': %s", annotation, o.GetNamespace(), o.GetName(), err.Error())
+		return def
+	}
+
+	return v
+}
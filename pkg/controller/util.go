@@ -20,7 +20,20 @@ func (c *Controller) MakeVars(o metav1.Object, typ string, namespaced bool) map[
 	} else {
 		nsvar = ""
 	}
-	return mergeVars(c.DefaultVars, map[string]string{VarName: o.GetName(), VarType: typ, VarCluster: c.Tag, VarNamespace: nsvar})
+	vars := mergeVars(c.DefaultVars, map[string]string{VarName: o.GetName(), VarType: typ, VarCluster: c.Tag, VarNamespace: nsvar})
+	if d, ok := o.(derivedObject); ok {
+		vars = mergeVars(vars, d.Vars())
+	}
+	return vars
+}
+
+// Vars for an Icinga object that checks o. In http check mode, this includes the URL of the check endpoint for o.
+func (c *Controller) MakeCheckVars(o metav1.Object, typ string, namespaced bool) map[string]string {
+	vars := c.MakeVars(o, typ, namespaced)
+	if c.CheckMode == CheckModeHTTP {
+		vars[VarCheckURL] = c.checkURL(typ, vars[VarNamespace], o.GetName())
+	}
+	return vars
 }
 
 func varsDiffer(a icinga2.Vars, b icinga2.Vars) bool {
@@ -59,10 +72,7 @@ func Vars(m map[string]string) icinga2.Vars {
 
 // True if this object should be monitored
 func (c *Controller) monitored(o metav1.Object) bool {
-	if a, ok := o.GetAnnotations()[AnnDisableMonitoring]; ok && a != "" {
-		return false
-	}
-	if len(o.GetOwnerReferences()) > 0 {
+	if !monitoredObject(o) {
 		return false
 	}
 	if ns := o.GetNamespace(); ns != "" {
@@ -79,6 +89,14 @@ func (c *Controller) monitored(o metav1.Object) bool {
 	return true
 }
 
+// True if this object should be monitored, not looking at its namespace.
+func monitoredObject(o metav1.Object) bool {
+	if a, ok := o.GetAnnotations()[AnnDisableMonitoring]; ok && a != "" {
+		return false
+	}
+	return len(o.GetOwnerReferences()) == 0
+}
+
 // Create an event for an object.
 func MakeEvent(kube kubernetes.Interface, o metav1.Object, message, kind string, warn bool) error {
 	var t string
@@ -102,6 +120,7 @@ func MakeEvent(kube kubernetes.Interface, o metav1.Object, message, kind string,
 			ResourceVersion: o.GetResourceVersion(),
 		},
 		Message:        message,
+		Source:         corev1.EventSource{Component: EventSource},
 		FirstTimestamp: metav1.Now(),
 		LastTimestamp:  metav1.Now(),
 		Type:           t,
@@ -136,9 +155,25 @@ func MakeObjectMeta(o metav1.Object, ownerKind, ownerApiVersion, objectAbbrev st
 		name = objectAbbrev + "-" + name
 	}
 
+	owner := o
+	if d, ok := o.(derivedObject); ok {
+		owner = d.Owner()
+	}
+
 	return metav1.ObjectMeta{
 		Name:            name,
 		Namespace:       namespace,
-		OwnerReferences: MakeOwnerRef(o, ownerKind, ownerApiVersion),
+		OwnerReferences: MakeOwnerRef(owner, ownerKind, ownerApiVersion),
 	}
 }
+
+// Implemented by monitored objects that are not Kubernetes objects themselves, like Helm releases.
+type derivedObject interface {
+	metav1.Object
+
+	// The Kubernetes object that owns the Icinga objects.
+	Owner() metav1.Object
+
+	// Additional vars for the Icinga objects.
+	Vars() map[string]string
+}
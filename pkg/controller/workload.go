@@ -3,27 +3,42 @@ package main
 import (
 	log "github.com/sirupsen/logrus"
 
-	appsv1beta2 "k8s.io/api/apps/v1beta2"
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
-	extensionsv1beta1 "k8s.io/api/extensions/v1beta1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/Nexinto/kubernetes-icinga/pkg/health"
 )
 
 func (c *Controller) PodCreatedOrUpdated(pod *corev1.Pod) error {
+	if !c.ComponentStatuses && controlPlanePod(pod) {
+		return c.processControlPlanePod(pod)
+	}
 	return c.processWorkload(pod, "po", "pod", "Pod", "v1")
 }
 
 func (c *Controller) PodDeleted(pod *corev1.Pod) error {
 	log.Debugf("processing deleted pod '%s/%s'", pod.Namespace, pod.Name)
+	if !c.ComponentStatuses && controlPlanePod(pod) {
+		return c.Mapping.UnmonitorControlPlanePod(c, pod)
+	}
 	return c.Mapping.UnmonitorWorkload(c, pod, "po")
 }
 
 func (c *Controller) NodeCreatedOrUpdated(node *corev1.Node) error {
 	log.Debugf("processing node '%s'", node.Name)
+	if c.NodeConditionChecks {
+		c.processNodeConditions(node)
+	}
 	if node.GetDeletionTimestamp() != nil {
 		return c.Mapping.UnmonitorNode(c, node)
 	} else {
-		return c.Mapping.MonitorNode(c, node)
+		if err := c.Mapping.MonitorNode(c, node); err != nil {
+			return err
+		}
+		typ, name := c.Mapping.NodeCheckable(c, node)
+		c.submitCheckResult(typ, name, node)
+		return nil
 	}
 }
 
@@ -48,49 +63,96 @@ func (c *Controller) NamespaceDeleted(namespace *corev1.Namespace) error {
 	return c.Mapping.UnmonitorNamespace(c, namespace)
 }
 
-func (c *Controller) DeploymentCreatedOrUpdated(deployment *extensionsv1beta1.Deployment) error {
-	return c.processWorkload(deployment, "deploy", "deployment", "Deployment", "v1beta1")
+func (c *Controller) ServiceCreatedOrUpdated(service *corev1.Service) error {
+	return c.processWorkload(service, "svc", "service", "Service", "v1")
+}
+
+func (c *Controller) ServiceDeleted(service *corev1.Service) error {
+	log.Debugf("processing deleted service '%s/%s'", service.Namespace, service.Name)
+	return c.Mapping.UnmonitorWorkload(c, service, "svc")
+}
+
+// Endpoints are not monitored themselves, but change the state of their service.
+func (c *Controller) EndpointsCreatedOrUpdated(endpoints *corev1.Endpoints) error {
+	c.requeueService(endpoints)
+	c.requeueWebhookConfigurations(endpoints)
+	return nil
+}
+
+func (c *Controller) EndpointsDeleted(endpoints *corev1.Endpoints) error {
+	c.requeueService(endpoints)
+	c.requeueWebhookConfigurations(endpoints)
+	return nil
+}
+
+func (c *Controller) requeueService(endpoints *corev1.Endpoints) {
+	if _, err := c.ServiceLister.Services(endpoints.Namespace).Get(endpoints.Name); err == nil {
+		c.ServiceQueue.Add(endpoints.Namespace + "/" + endpoints.Name)
+	}
+}
+
+func (c *Controller) DeploymentCreatedOrUpdated(deployment *appsv1.Deployment) error {
+	c.processRollout(deployment, "deploy", "deployment", "Deployment", func() health.Rollout {
+		return health.DeploymentRollout(deployment, c.deploymentCurrentRevision(deployment))
+	})
+	return c.processWorkload(deployment, "deploy", "deployment", "Deployment", "apps/v1")
 }
 
-func (c *Controller) DeploymentDeleted(deployment *extensionsv1beta1.Deployment) error {
+func (c *Controller) DeploymentDeleted(deployment *appsv1.Deployment) error {
 	log.Debugf("processing deleted deployment '%s/%s'", deployment.Namespace, deployment.Name)
+	c.rolloutDeleted(deployment, "deploy", "Deployment")
 	return c.Mapping.UnmonitorWorkload(c, deployment, "deploy")
 }
 
-func (c *Controller) DaemonSetCreatedOrUpdated(daemonset *extensionsv1beta1.DaemonSet) error {
-	return c.processWorkload(daemonset, "ds", "daemonset", "DaemonSet", "v1beta1")
+func (c *Controller) DaemonSetCreatedOrUpdated(daemonset *appsv1.DaemonSet) error {
+	c.processDaemonSetNodes(daemonset)
+	c.processRollout(daemonset, "ds", "daemonset", "DaemonSet", func() health.Rollout {
+		return health.DaemonSetRollout(daemonset)
+	})
+	return c.processWorkload(daemonset, "ds", "daemonset", "DaemonSet", "apps/v1")
 }
 
-func (c *Controller) DaemonSetDeleted(daemonset *extensionsv1beta1.DaemonSet) error {
+func (c *Controller) DaemonSetDeleted(daemonset *appsv1.DaemonSet) error {
 	log.Debugf("processing deleted daemonset '%s/%s'", daemonset.Namespace, daemonset.Name)
+	c.rolloutDeleted(daemonset, "ds", "DaemonSet")
 	return c.Mapping.UnmonitorWorkload(c, daemonset, "ds")
 }
 
-func (c *Controller) ReplicaSetCreatedOrUpdated(replicaset *extensionsv1beta1.ReplicaSet) error {
-	return c.processWorkload(replicaset, "rs", "replicaset", "ReplicaSet", "v1beta1")
+func (c *Controller) ReplicaSetCreatedOrUpdated(replicaset *appsv1.ReplicaSet) error {
+	return c.processWorkload(replicaset, "rs", "replicaset", "ReplicaSet", "apps/v1")
 }
 
-func (c *Controller) ReplicaSetDeleted(replicaset *extensionsv1beta1.ReplicaSet) error {
+func (c *Controller) ReplicaSetDeleted(replicaset *appsv1.ReplicaSet) error {
 	log.Debugf("processing deleted replicaset '%s/%s'", replicaset.Namespace, replicaset.Name)
 	return c.Mapping.UnmonitorWorkload(c, replicaset, "rs")
 }
 
-func (c *Controller) StatefulSetCreatedOrUpdated(statefulset *appsv1beta2.StatefulSet) error {
-	return c.processWorkload(statefulset, "statefulset", "statefulset", "StatefulSet", "v1beta2")
+func (c *Controller) StatefulSetCreatedOrUpdated(statefulset *appsv1.StatefulSet) error {
+	c.processStatefulSetReplicas(statefulset)
+	c.processRollout(statefulset, "statefulset", "statefulset", "StatefulSet", func() health.Rollout {
+		return health.StatefulSetRollout(statefulset)
+	})
+	return c.processWorkload(statefulset, "statefulset", "statefulset", "StatefulSet", "apps/v1")
 }
 
-func (c *Controller) StatefulSetDeleted(statefulset *appsv1beta2.StatefulSet) error {
+func (c *Controller) StatefulSetDeleted(statefulset *appsv1.StatefulSet) error {
 	log.Debugf("processing deleted statefulset '%s/%s'", statefulset.Namespace, statefulset.Name)
+	c.rolloutDeleted(statefulset, "statefulset", "StatefulSet")
 	return c.Mapping.UnmonitorWorkload(c, statefulset, "statefulset")
 }
 
 func (c *Controller) processWorkload(o metav1.Object, abbrev, typ, kind, apiVersion string) error {
 	log.Debugf("processing %s '%s/%s'", typ, o.GetNamespace(), o.GetName())
-	if !c.monitored(o) {
+	if !c.monitored(o) || !c.checkable(typ) {
 		return c.Mapping.UnmonitorWorkload(c, o, abbrev)
 	} else if o.GetDeletionTimestamp() != nil {
 		return c.Mapping.UnmonitorWorkload(c, o, abbrev)
 	} else {
-		return c.Mapping.MonitorWorkload(c, o, abbrev, typ, kind, apiVersion)
+		if err := c.Mapping.MonitorWorkload(c, o, abbrev, typ, kind, apiVersion); err != nil {
+			return err
+		}
+		checkableType, name := c.Mapping.WorkloadCheckable(c, o, abbrev)
+		c.submitCheckResult(checkableType, name, o)
+		return nil
 	}
 }
@@ -21,13 +21,17 @@ import (
 
 	corelisterv1 "k8s.io/client-go/listers/core/v1"
 
-	extensionsv1beta1 "k8s.io/api/extensions/v1beta1"
+	appsv1 "k8s.io/api/apps/v1"
 
-	extensionslisterv1beta1 "k8s.io/client-go/listers/extensions/v1beta1"
+	appslisterv1 "k8s.io/client-go/listers/apps/v1"
 
-	appsv1beta2 "k8s.io/api/apps/v1beta2"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
 
-	appslisterv1beta2 "k8s.io/client-go/listers/apps/v1beta2"
+	admissionregistrationlisterv1 "k8s.io/client-go/listers/admissionregistration/v1"
+
+	batchv1 "k8s.io/api/batch/v1"
+
+	batchlisterv1 "k8s.io/client-go/listers/batch/v1"
 
 	icingaclientset "github.com/Nexinto/kubernetes-icinga/pkg/client/clientset/versioned"
 
@@ -35,6 +39,12 @@ import (
 	icingav1 "github.com/Nexinto/kubernetes-icinga/pkg/apis/icinga.nexinto.com/v1"
 	icingainformers "github.com/Nexinto/kubernetes-icinga/pkg/client/informers/externalversions"
 	icingalisterv1 "github.com/Nexinto/kubernetes-icinga/pkg/client/listers/icinga.nexinto.com/v1"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/dynamic/dynamicinformer"
+	autoscalinglisterv2beta2 "k8s.io/client-go/listers/autoscaling/v2beta2"
+	batchlisterv1beta1 "k8s.io/client-go/listers/batch/v1beta1"
+	networkinglisterv1beta1 "k8s.io/client-go/listers/networking/v1beta1"
+	policylisterv1beta1 "k8s.io/client-go/listers/policy/v1beta1"
 )
 
 type Controller struct {
@@ -53,22 +63,54 @@ type Controller struct {
 	NamespaceLister corelisterv1.NamespaceLister
 	NamespaceSynced cache.InformerSynced
 
+	ServiceQueue  workqueue.RateLimitingInterface
+	ServiceLister corelisterv1.ServiceLister
+	ServiceSynced cache.InformerSynced
+
+	EndpointsQueue  workqueue.RateLimitingInterface
+	EndpointsLister corelisterv1.EndpointsLister
+	EndpointsSynced cache.InformerSynced
+
+	PersistentVolumeClaimQueue  workqueue.RateLimitingInterface
+	PersistentVolumeClaimLister corelisterv1.PersistentVolumeClaimLister
+	PersistentVolumeClaimSynced cache.InformerSynced
+
+	ResourceQuotaQueue  workqueue.RateLimitingInterface
+	ResourceQuotaLister corelisterv1.ResourceQuotaLister
+	ResourceQuotaSynced cache.InformerSynced
+
+	PersistentVolumeQueue  workqueue.RateLimitingInterface
+	PersistentVolumeLister corelisterv1.PersistentVolumeLister
+	PersistentVolumeSynced cache.InformerSynced
+
 	DeploymentQueue  workqueue.RateLimitingInterface
-	DeploymentLister extensionslisterv1beta1.DeploymentLister
+	DeploymentLister appslisterv1.DeploymentLister
 	DeploymentSynced cache.InformerSynced
 
 	DaemonSetQueue  workqueue.RateLimitingInterface
-	DaemonSetLister extensionslisterv1beta1.DaemonSetLister
+	DaemonSetLister appslisterv1.DaemonSetLister
 	DaemonSetSynced cache.InformerSynced
 
 	ReplicaSetQueue  workqueue.RateLimitingInterface
-	ReplicaSetLister extensionslisterv1beta1.ReplicaSetLister
+	ReplicaSetLister appslisterv1.ReplicaSetLister
 	ReplicaSetSynced cache.InformerSynced
 
 	StatefulSetQueue  workqueue.RateLimitingInterface
-	StatefulSetLister appslisterv1beta2.StatefulSetLister
+	StatefulSetLister appslisterv1.StatefulSetLister
 	StatefulSetSynced cache.InformerSynced
 
+	ValidatingWebhookConfigurationQueue  workqueue.RateLimitingInterface
+	ValidatingWebhookConfigurationLister admissionregistrationlisterv1.ValidatingWebhookConfigurationLister
+	ValidatingWebhookConfigurationSynced cache.InformerSynced
+
+	MutatingWebhookConfigurationQueue  workqueue.RateLimitingInterface
+	MutatingWebhookConfigurationLister admissionregistrationlisterv1.MutatingWebhookConfigurationLister
+	MutatingWebhookConfigurationSynced cache.InformerSynced
+
+	JobQueue  workqueue.RateLimitingInterface
+	JobLister batchlisterv1.JobLister
+	JobSynced cache.InformerSynced
+
 	IcingaClient  icingaclientset.Interface
 	IcingaFactory icingainformers.SharedInformerFactory
 
@@ -84,10 +126,77 @@ type Controller struct {
 	CheckLister icingalisterv1.CheckLister
 	CheckSynced cache.InformerSynced
 
-	Icinga      icinga2.Client
-	Tag         string
-	DefaultVars map[string]string
-	Mapping     Mapping
+	Icinga                        icinga2.Client
+	IcingaAPI                     IcingaAPI
+	Tag                           string
+	DefaultVars                   map[string]string
+	Mapping                       Mapping
+	CheckMode                     string
+	PassiveTTL                    int
+	Results                       resultTracker
+	LoadBalancerGrace             time.Duration
+	JobRetention                  time.Duration
+	CronJobWindowRuns             int
+	PVCPendingGrace               time.Duration
+	HPAMaxReplicasGrace           time.Duration
+	PDBBlockedGrace               time.Duration
+	Disruptions                   disruptionTracker
+	ComponentStatuses             bool
+	StuckDeletionGrace            time.Duration
+	RestartWindow                 time.Duration
+	RestartWarning                int
+	PodPendingGrace               time.Duration
+	Restarts                      restartTracker
+	RolloutChecks                 bool
+	RolloutGrace                  time.Duration
+	Rollouts                      rolloutTracker
+	NodeConditionChecks           bool
+	NodeLeaseGrace                time.Duration
+	UsageWarning                  int
+	UsageCritical                 int
+	QuotaWarning                  int
+	QuotaCritical                 int
+	HTTPCheckCommand              string
+	CheckURL                      string
+	CheckToken                    string
+	TLSWarningDays                int
+	TLSCriticalDays               int
+	EventWindow                   time.Duration
+	EventWarning                  int
+	EventCritical                 int
+	EventIgnoredReasons           []string
+	EventFactory                  kubernetesinformers.SharedInformerFactory
+	EventQueue                    workqueue.RateLimitingInterface
+	EventLister                   corelisterv1.EventLister
+	EventSynced                   cache.InformerSynced
+	HelmPendingGrace              time.Duration
+	HelmFactory                   kubernetesinformers.SharedInformerFactory
+	HelmQueue                     workqueue.RateLimitingInterface
+	HelmSecretLister              corelisterv1.SecretLister
+	HelmSynced                    cache.InformerSynced
+	SecretFactory                 kubernetesinformers.SharedInformerFactory
+	SecretQueue                   workqueue.RateLimitingInterface
+	SecretLister                  corelisterv1.SecretLister
+	SecretSynced                  cache.InformerSynced
+	Dynamic                       dynamic.Interface
+	DynamicFactory                dynamicinformer.DynamicSharedInformerFactory
+	APIServiceFactory             dynamicinformer.DynamicSharedInformerFactory
+	APIServiceQueue               workqueue.RateLimitingInterface
+	APIServiceLister              cache.GenericLister
+	APIServiceSynced              cache.InformerSynced
+	MonitoredResources            []*MonitoredResource
+	IngressQueue                  workqueue.RateLimitingInterface
+	IngressLister                 networkinglisterv1beta1.IngressLister
+	IngressSynced                 cache.InformerSynced
+	CronJobQueue                  workqueue.RateLimitingInterface
+	CronJobLister                 batchlisterv1beta1.CronJobLister
+	CronJobSynced                 cache.InformerSynced
+	HorizontalPodAutoscalerQueue  workqueue.RateLimitingInterface
+	HorizontalPodAutoscalerLister autoscalinglisterv2beta2.HorizontalPodAutoscalerLister
+	HorizontalPodAutoscalerSynced cache.InformerSynced
+	PodDisruptionBudgetQueue      workqueue.RateLimitingInterface
+	PodDisruptionBudgetLister     policylisterv1beta1.PodDisruptionBudgetLister
+	PodDisruptionBudgetSynced     cache.InformerSynced
 }
 
 // Expects the clientsets to be set.
@@ -230,7 +339,227 @@ func (c *Controller) Initialize() {
 		},
 	})
 
-	DeploymentInformer := c.KubernetesFactory.Extensions().V1beta1().Deployments()
+	ServiceInformer := c.KubernetesFactory.Core().V1().Services()
+	ServiceQueue := workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
+	c.ServiceQueue = ServiceQueue
+	c.ServiceLister = ServiceInformer.Lister()
+	c.ServiceSynced = ServiceInformer.Informer().HasSynced
+
+	ServiceInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+
+		AddFunc: func(obj interface{}) {
+			if key, err := cache.MetaNamespaceKeyFunc(obj); err == nil {
+				ServiceQueue.Add(key)
+			}
+		},
+
+		UpdateFunc: func(old, new interface{}) {
+			if key, err := cache.MetaNamespaceKeyFunc(new); err == nil {
+				ServiceQueue.Add(key)
+			}
+		},
+
+		DeleteFunc: func(obj interface{}) {
+			o, ok := obj.(*corev1.Service)
+
+			if !ok {
+				tombstone, ok := obj.(cache.DeletedFinalStateUnknown)
+				if !ok {
+					log.Errorf("couldn't get object from tombstone %+v", obj)
+					return
+				}
+				o, ok = tombstone.Obj.(*corev1.Service)
+				if !ok {
+					log.Errorf("tombstone contained object that is not a Service %+v", obj)
+					return
+				}
+			}
+
+			err := c.ServiceDeleted(o)
+
+			if err != nil {
+				log.Errorf("failed to process deletion: %s", err.Error())
+			}
+		},
+	})
+
+	EndpointsInformer := c.KubernetesFactory.Core().V1().Endpoints()
+	EndpointsQueue := workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
+	c.EndpointsQueue = EndpointsQueue
+	c.EndpointsLister = EndpointsInformer.Lister()
+	c.EndpointsSynced = EndpointsInformer.Informer().HasSynced
+
+	EndpointsInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+
+		AddFunc: func(obj interface{}) {
+			if key, err := cache.MetaNamespaceKeyFunc(obj); err == nil {
+				EndpointsQueue.Add(key)
+			}
+		},
+
+		UpdateFunc: func(old, new interface{}) {
+			if key, err := cache.MetaNamespaceKeyFunc(new); err == nil {
+				EndpointsQueue.Add(key)
+			}
+		},
+
+		DeleteFunc: func(obj interface{}) {
+			o, ok := obj.(*corev1.Endpoints)
+
+			if !ok {
+				tombstone, ok := obj.(cache.DeletedFinalStateUnknown)
+				if !ok {
+					log.Errorf("couldn't get object from tombstone %+v", obj)
+					return
+				}
+				o, ok = tombstone.Obj.(*corev1.Endpoints)
+				if !ok {
+					log.Errorf("tombstone contained object that is not a Endpoints %+v", obj)
+					return
+				}
+			}
+
+			err := c.EndpointsDeleted(o)
+
+			if err != nil {
+				log.Errorf("failed to process deletion: %s", err.Error())
+			}
+		},
+	})
+
+	PersistentVolumeClaimInformer := c.KubernetesFactory.Core().V1().PersistentVolumeClaims()
+	PersistentVolumeClaimQueue := workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
+	c.PersistentVolumeClaimQueue = PersistentVolumeClaimQueue
+	c.PersistentVolumeClaimLister = PersistentVolumeClaimInformer.Lister()
+	c.PersistentVolumeClaimSynced = PersistentVolumeClaimInformer.Informer().HasSynced
+
+	PersistentVolumeClaimInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+
+		AddFunc: func(obj interface{}) {
+			if key, err := cache.MetaNamespaceKeyFunc(obj); err == nil {
+				PersistentVolumeClaimQueue.Add(key)
+			}
+		},
+
+		UpdateFunc: func(old, new interface{}) {
+			if key, err := cache.MetaNamespaceKeyFunc(new); err == nil {
+				PersistentVolumeClaimQueue.Add(key)
+			}
+		},
+
+		DeleteFunc: func(obj interface{}) {
+			o, ok := obj.(*corev1.PersistentVolumeClaim)
+
+			if !ok {
+				tombstone, ok := obj.(cache.DeletedFinalStateUnknown)
+				if !ok {
+					log.Errorf("couldn't get object from tombstone %+v", obj)
+					return
+				}
+				o, ok = tombstone.Obj.(*corev1.PersistentVolumeClaim)
+				if !ok {
+					log.Errorf("tombstone contained object that is not a PersistentVolumeClaim %+v", obj)
+					return
+				}
+			}
+
+			err := c.PersistentVolumeClaimDeleted(o)
+
+			if err != nil {
+				log.Errorf("failed to process deletion: %s", err.Error())
+			}
+		},
+	})
+
+	ResourceQuotaInformer := c.KubernetesFactory.Core().V1().ResourceQuotas()
+	ResourceQuotaQueue := workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
+	c.ResourceQuotaQueue = ResourceQuotaQueue
+	c.ResourceQuotaLister = ResourceQuotaInformer.Lister()
+	c.ResourceQuotaSynced = ResourceQuotaInformer.Informer().HasSynced
+
+	ResourceQuotaInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+
+		AddFunc: func(obj interface{}) {
+			if key, err := cache.MetaNamespaceKeyFunc(obj); err == nil {
+				ResourceQuotaQueue.Add(key)
+			}
+		},
+
+		UpdateFunc: func(old, new interface{}) {
+			if key, err := cache.MetaNamespaceKeyFunc(new); err == nil {
+				ResourceQuotaQueue.Add(key)
+			}
+		},
+
+		DeleteFunc: func(obj interface{}) {
+			o, ok := obj.(*corev1.ResourceQuota)
+
+			if !ok {
+				tombstone, ok := obj.(cache.DeletedFinalStateUnknown)
+				if !ok {
+					log.Errorf("couldn't get object from tombstone %+v", obj)
+					return
+				}
+				o, ok = tombstone.Obj.(*corev1.ResourceQuota)
+				if !ok {
+					log.Errorf("tombstone contained object that is not a ResourceQuota %+v", obj)
+					return
+				}
+			}
+
+			err := c.ResourceQuotaDeleted(o)
+
+			if err != nil {
+				log.Errorf("failed to process deletion: %s", err.Error())
+			}
+		},
+	})
+
+	PersistentVolumeInformer := c.KubernetesFactory.Core().V1().PersistentVolumes()
+	PersistentVolumeQueue := workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
+	c.PersistentVolumeQueue = PersistentVolumeQueue
+	c.PersistentVolumeLister = PersistentVolumeInformer.Lister()
+	c.PersistentVolumeSynced = PersistentVolumeInformer.Informer().HasSynced
+
+	PersistentVolumeInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+
+		AddFunc: func(obj interface{}) {
+			if key, err := cache.MetaNamespaceKeyFunc(obj); err == nil {
+				PersistentVolumeQueue.Add(key)
+			}
+		},
+
+		UpdateFunc: func(old, new interface{}) {
+			if key, err := cache.MetaNamespaceKeyFunc(new); err == nil {
+				PersistentVolumeQueue.Add(key)
+			}
+		},
+
+		DeleteFunc: func(obj interface{}) {
+			o, ok := obj.(*corev1.PersistentVolume)
+
+			if !ok {
+				tombstone, ok := obj.(cache.DeletedFinalStateUnknown)
+				if !ok {
+					log.Errorf("couldn't get object from tombstone %+v", obj)
+					return
+				}
+				o, ok = tombstone.Obj.(*corev1.PersistentVolume)
+				if !ok {
+					log.Errorf("tombstone contained object that is not a PersistentVolume %+v", obj)
+					return
+				}
+			}
+
+			err := c.PersistentVolumeDeleted(o)
+
+			if err != nil {
+				log.Errorf("failed to process deletion: %s", err.Error())
+			}
+		},
+	})
+
+	DeploymentInformer := c.KubernetesFactory.Apps().V1().Deployments()
 	DeploymentQueue := workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
 	c.DeploymentQueue = DeploymentQueue
 	c.DeploymentLister = DeploymentInformer.Lister()
@@ -251,7 +580,7 @@ func (c *Controller) Initialize() {
 		},
 
 		DeleteFunc: func(obj interface{}) {
-			o, ok := obj.(*extensionsv1beta1.Deployment)
+			o, ok := obj.(*appsv1.Deployment)
 
 			if !ok {
 				tombstone, ok := obj.(cache.DeletedFinalStateUnknown)
@@ -259,7 +588,7 @@ func (c *Controller) Initialize() {
 					log.Errorf("couldn't get object from tombstone %+v", obj)
 					return
 				}
-				o, ok = tombstone.Obj.(*extensionsv1beta1.Deployment)
+				o, ok = tombstone.Obj.(*appsv1.Deployment)
 				if !ok {
 					log.Errorf("tombstone contained object that is not a Deployment %+v", obj)
 					return
@@ -274,7 +603,7 @@ func (c *Controller) Initialize() {
 		},
 	})
 
-	DaemonSetInformer := c.KubernetesFactory.Extensions().V1beta1().DaemonSets()
+	DaemonSetInformer := c.KubernetesFactory.Apps().V1().DaemonSets()
 	DaemonSetQueue := workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
 	c.DaemonSetQueue = DaemonSetQueue
 	c.DaemonSetLister = DaemonSetInformer.Lister()
@@ -295,7 +624,7 @@ func (c *Controller) Initialize() {
 		},
 
 		DeleteFunc: func(obj interface{}) {
-			o, ok := obj.(*extensionsv1beta1.DaemonSet)
+			o, ok := obj.(*appsv1.DaemonSet)
 
 			if !ok {
 				tombstone, ok := obj.(cache.DeletedFinalStateUnknown)
@@ -303,7 +632,7 @@ func (c *Controller) Initialize() {
 					log.Errorf("couldn't get object from tombstone %+v", obj)
 					return
 				}
-				o, ok = tombstone.Obj.(*extensionsv1beta1.DaemonSet)
+				o, ok = tombstone.Obj.(*appsv1.DaemonSet)
 				if !ok {
 					log.Errorf("tombstone contained object that is not a DaemonSet %+v", obj)
 					return
@@ -318,7 +647,7 @@ func (c *Controller) Initialize() {
 		},
 	})
 
-	ReplicaSetInformer := c.KubernetesFactory.Extensions().V1beta1().ReplicaSets()
+	ReplicaSetInformer := c.KubernetesFactory.Apps().V1().ReplicaSets()
 	ReplicaSetQueue := workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
 	c.ReplicaSetQueue = ReplicaSetQueue
 	c.ReplicaSetLister = ReplicaSetInformer.Lister()
@@ -339,7 +668,7 @@ func (c *Controller) Initialize() {
 		},
 
 		DeleteFunc: func(obj interface{}) {
-			o, ok := obj.(*extensionsv1beta1.ReplicaSet)
+			o, ok := obj.(*appsv1.ReplicaSet)
 
 			if !ok {
 				tombstone, ok := obj.(cache.DeletedFinalStateUnknown)
@@ -347,7 +676,7 @@ func (c *Controller) Initialize() {
 					log.Errorf("couldn't get object from tombstone %+v", obj)
 					return
 				}
-				o, ok = tombstone.Obj.(*extensionsv1beta1.ReplicaSet)
+				o, ok = tombstone.Obj.(*appsv1.ReplicaSet)
 				if !ok {
 					log.Errorf("tombstone contained object that is not a ReplicaSet %+v", obj)
 					return
@@ -362,7 +691,7 @@ func (c *Controller) Initialize() {
 		},
 	})
 
-	StatefulSetInformer := c.KubernetesFactory.Apps().V1beta2().StatefulSets()
+	StatefulSetInformer := c.KubernetesFactory.Apps().V1().StatefulSets()
 	StatefulSetQueue := workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
 	c.StatefulSetQueue = StatefulSetQueue
 	c.StatefulSetLister = StatefulSetInformer.Lister()
@@ -383,7 +712,7 @@ func (c *Controller) Initialize() {
 		},
 
 		DeleteFunc: func(obj interface{}) {
-			o, ok := obj.(*appsv1beta2.StatefulSet)
+			o, ok := obj.(*appsv1.StatefulSet)
 
 			if !ok {
 				tombstone, ok := obj.(cache.DeletedFinalStateUnknown)
@@ -391,7 +720,7 @@ func (c *Controller) Initialize() {
 					log.Errorf("couldn't get object from tombstone %+v", obj)
 					return
 				}
-				o, ok = tombstone.Obj.(*appsv1beta2.StatefulSet)
+				o, ok = tombstone.Obj.(*appsv1.StatefulSet)
 				if !ok {
 					log.Errorf("tombstone contained object that is not a StatefulSet %+v", obj)
 					return
@@ -406,33 +735,28 @@ func (c *Controller) Initialize() {
 		},
 	})
 
-	if c.IcingaClient == nil {
-		panic("c.IcingaClient is nil")
-	}
-	c.IcingaFactory = icingainformers.NewSharedInformerFactory(c.IcingaClient, time.Second*60)
-
-	HostGroupInformer := c.IcingaFactory.Icinga().V1().HostGroups()
-	HostGroupQueue := workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
-	c.HostGroupQueue = HostGroupQueue
-	c.HostGroupLister = HostGroupInformer.Lister()
-	c.HostGroupSynced = HostGroupInformer.Informer().HasSynced
+	ValidatingWebhookConfigurationInformer := c.KubernetesFactory.Admissionregistration().V1().ValidatingWebhookConfigurations()
+	ValidatingWebhookConfigurationQueue := workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
+	c.ValidatingWebhookConfigurationQueue = ValidatingWebhookConfigurationQueue
+	c.ValidatingWebhookConfigurationLister = ValidatingWebhookConfigurationInformer.Lister()
+	c.ValidatingWebhookConfigurationSynced = ValidatingWebhookConfigurationInformer.Informer().HasSynced
 
-	HostGroupInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+	ValidatingWebhookConfigurationInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
 
 		AddFunc: func(obj interface{}) {
 			if key, err := cache.MetaNamespaceKeyFunc(obj); err == nil {
-				HostGroupQueue.Add(key)
+				ValidatingWebhookConfigurationQueue.Add(key)
 			}
 		},
 
 		UpdateFunc: func(old, new interface{}) {
 			if key, err := cache.MetaNamespaceKeyFunc(new); err == nil {
-				HostGroupQueue.Add(key)
+				ValidatingWebhookConfigurationQueue.Add(key)
 			}
 		},
 
 		DeleteFunc: func(obj interface{}) {
-			o, ok := obj.(*icingav1.HostGroup)
+			o, ok := obj.(*admissionregistrationv1.ValidatingWebhookConfiguration)
 
 			if !ok {
 				tombstone, ok := obj.(cache.DeletedFinalStateUnknown)
@@ -440,14 +764,14 @@ func (c *Controller) Initialize() {
 					log.Errorf("couldn't get object from tombstone %+v", obj)
 					return
 				}
-				o, ok = tombstone.Obj.(*icingav1.HostGroup)
+				o, ok = tombstone.Obj.(*admissionregistrationv1.ValidatingWebhookConfiguration)
 				if !ok {
-					log.Errorf("tombstone contained object that is not a HostGroup %+v", obj)
+					log.Errorf("tombstone contained object that is not a ValidatingWebhookConfiguration %+v", obj)
 					return
 				}
 			}
 
-			err := c.HostGroupDeleted(o)
+			err := c.ValidatingWebhookConfigurationDeleted(o)
 
 			if err != nil {
 				log.Errorf("failed to process deletion: %s", err.Error())
@@ -455,28 +779,28 @@ func (c *Controller) Initialize() {
 		},
 	})
 
-	HostInformer := c.IcingaFactory.Icinga().V1().Hosts()
-	HostQueue := workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
-	c.HostQueue = HostQueue
-	c.HostLister = HostInformer.Lister()
-	c.HostSynced = HostInformer.Informer().HasSynced
+	MutatingWebhookConfigurationInformer := c.KubernetesFactory.Admissionregistration().V1().MutatingWebhookConfigurations()
+	MutatingWebhookConfigurationQueue := workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
+	c.MutatingWebhookConfigurationQueue = MutatingWebhookConfigurationQueue
+	c.MutatingWebhookConfigurationLister = MutatingWebhookConfigurationInformer.Lister()
+	c.MutatingWebhookConfigurationSynced = MutatingWebhookConfigurationInformer.Informer().HasSynced
 
-	HostInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+	MutatingWebhookConfigurationInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
 
 		AddFunc: func(obj interface{}) {
 			if key, err := cache.MetaNamespaceKeyFunc(obj); err == nil {
-				HostQueue.Add(key)
+				MutatingWebhookConfigurationQueue.Add(key)
 			}
 		},
 
 		UpdateFunc: func(old, new interface{}) {
 			if key, err := cache.MetaNamespaceKeyFunc(new); err == nil {
-				HostQueue.Add(key)
+				MutatingWebhookConfigurationQueue.Add(key)
 			}
 		},
 
 		DeleteFunc: func(obj interface{}) {
-			o, ok := obj.(*icingav1.Host)
+			o, ok := obj.(*admissionregistrationv1.MutatingWebhookConfiguration)
 
 			if !ok {
 				tombstone, ok := obj.(cache.DeletedFinalStateUnknown)
@@ -484,14 +808,14 @@ func (c *Controller) Initialize() {
 					log.Errorf("couldn't get object from tombstone %+v", obj)
 					return
 				}
-				o, ok = tombstone.Obj.(*icingav1.Host)
+				o, ok = tombstone.Obj.(*admissionregistrationv1.MutatingWebhookConfiguration)
 				if !ok {
-					log.Errorf("tombstone contained object that is not a Host %+v", obj)
+					log.Errorf("tombstone contained object that is not a MutatingWebhookConfiguration %+v", obj)
 					return
 				}
 			}
 
-			err := c.HostDeleted(o)
+			err := c.MutatingWebhookConfigurationDeleted(o)
 
 			if err != nil {
 				log.Errorf("failed to process deletion: %s", err.Error())
@@ -499,28 +823,28 @@ func (c *Controller) Initialize() {
 		},
 	})
 
-	CheckInformer := c.IcingaFactory.Icinga().V1().Checks()
-	CheckQueue := workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
-	c.CheckQueue = CheckQueue
-	c.CheckLister = CheckInformer.Lister()
-	c.CheckSynced = CheckInformer.Informer().HasSynced
+	JobInformer := c.KubernetesFactory.Batch().V1().Jobs()
+	JobQueue := workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
+	c.JobQueue = JobQueue
+	c.JobLister = JobInformer.Lister()
+	c.JobSynced = JobInformer.Informer().HasSynced
 
-	CheckInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+	JobInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
 
 		AddFunc: func(obj interface{}) {
 			if key, err := cache.MetaNamespaceKeyFunc(obj); err == nil {
-				CheckQueue.Add(key)
+				JobQueue.Add(key)
 			}
 		},
 
 		UpdateFunc: func(old, new interface{}) {
 			if key, err := cache.MetaNamespaceKeyFunc(new); err == nil {
-				CheckQueue.Add(key)
+				JobQueue.Add(key)
 			}
 		},
 
 		DeleteFunc: func(obj interface{}) {
-			o, ok := obj.(*icingav1.Check)
+			o, ok := obj.(*batchv1.Job)
 
 			if !ok {
 				tombstone, ok := obj.(cache.DeletedFinalStateUnknown)
@@ -528,14 +852,14 @@ func (c *Controller) Initialize() {
 					log.Errorf("couldn't get object from tombstone %+v", obj)
 					return
 				}
-				o, ok = tombstone.Obj.(*icingav1.Check)
+				o, ok = tombstone.Obj.(*batchv1.Job)
 				if !ok {
-					log.Errorf("tombstone contained object that is not a Check %+v", obj)
+					log.Errorf("tombstone contained object that is not a Job %+v", obj)
 					return
 				}
 			}
 
-			err := c.CheckDeleted(o)
+			err := c.JobDeleted(o)
 
 			if err != nil {
 				log.Errorf("failed to process deletion: %s", err.Error())
@@ -543,41 +867,186 @@ func (c *Controller) Initialize() {
 		},
 	})
 
-	return
-}
-
-func (c *Controller) Start() {
-	stopCh := make(chan struct{})
-	defer close(stopCh)
-	go c.KubernetesFactory.Start(stopCh)
-	go c.IcingaFactory.Start(stopCh)
+	if c.IcingaClient == nil {
+		panic("c.IcingaClient is nil")
+	}
+	c.IcingaFactory = icingainformers.NewSharedInformerFactory(c.IcingaClient, time.Second*60)
 
-	go c.Run(stopCh)
+	HostGroupInformer := c.IcingaFactory.Icinga().V1().HostGroups()
+	HostGroupQueue := workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
+	c.HostGroupQueue = HostGroupQueue
+	c.HostGroupLister = HostGroupInformer.Lister()
+	c.HostGroupSynced = HostGroupInformer.Informer().HasSynced
 
-	sigterm := make(chan os.Signal, 1)
-	signal.Notify(sigterm, syscall.SIGTERM)
-	signal.Notify(sigterm, syscall.SIGINT)
-	<-sigterm
-}
+	HostGroupInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
 
-func (c *Controller) Run(stopCh <-chan struct{}) {
+		AddFunc: func(obj interface{}) {
+			if key, err := cache.MetaNamespaceKeyFunc(obj); err == nil {
+				HostGroupQueue.Add(key)
+			}
+		},
 
-	log.Infof("starting controller")
+		UpdateFunc: func(old, new interface{}) {
+			if key, err := cache.MetaNamespaceKeyFunc(new); err == nil {
+				HostGroupQueue.Add(key)
+			}
+		},
 
-	defer runtime.HandleCrash()
+		DeleteFunc: func(obj interface{}) {
+			o, ok := obj.(*icingav1.HostGroup)
 
-	defer c.PodQueue.ShutDown()
-	defer c.NodeQueue.ShutDown()
-	defer c.NamespaceQueue.ShutDown()
-	defer c.DeploymentQueue.ShutDown()
-	defer c.DaemonSetQueue.ShutDown()
-	defer c.ReplicaSetQueue.ShutDown()
+			if !ok {
+				tombstone, ok := obj.(cache.DeletedFinalStateUnknown)
+				if !ok {
+					log.Errorf("couldn't get object from tombstone %+v", obj)
+					return
+				}
+				o, ok = tombstone.Obj.(*icingav1.HostGroup)
+				if !ok {
+					log.Errorf("tombstone contained object that is not a HostGroup %+v", obj)
+					return
+				}
+			}
+
+			err := c.HostGroupDeleted(o)
+
+			if err != nil {
+				log.Errorf("failed to process deletion: %s", err.Error())
+			}
+		},
+	})
+
+	HostInformer := c.IcingaFactory.Icinga().V1().Hosts()
+	HostQueue := workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
+	c.HostQueue = HostQueue
+	c.HostLister = HostInformer.Lister()
+	c.HostSynced = HostInformer.Informer().HasSynced
+
+	HostInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+
+		AddFunc: func(obj interface{}) {
+			if key, err := cache.MetaNamespaceKeyFunc(obj); err == nil {
+				HostQueue.Add(key)
+			}
+		},
+
+		UpdateFunc: func(old, new interface{}) {
+			if key, err := cache.MetaNamespaceKeyFunc(new); err == nil {
+				HostQueue.Add(key)
+			}
+		},
+
+		DeleteFunc: func(obj interface{}) {
+			o, ok := obj.(*icingav1.Host)
+
+			if !ok {
+				tombstone, ok := obj.(cache.DeletedFinalStateUnknown)
+				if !ok {
+					log.Errorf("couldn't get object from tombstone %+v", obj)
+					return
+				}
+				o, ok = tombstone.Obj.(*icingav1.Host)
+				if !ok {
+					log.Errorf("tombstone contained object that is not a Host %+v", obj)
+					return
+				}
+			}
+
+			err := c.HostDeleted(o)
+
+			if err != nil {
+				log.Errorf("failed to process deletion: %s", err.Error())
+			}
+		},
+	})
+
+	CheckInformer := c.IcingaFactory.Icinga().V1().Checks()
+	CheckQueue := workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
+	c.CheckQueue = CheckQueue
+	c.CheckLister = CheckInformer.Lister()
+	c.CheckSynced = CheckInformer.Informer().HasSynced
+
+	CheckInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+
+		AddFunc: func(obj interface{}) {
+			if key, err := cache.MetaNamespaceKeyFunc(obj); err == nil {
+				CheckQueue.Add(key)
+			}
+		},
+
+		UpdateFunc: func(old, new interface{}) {
+			if key, err := cache.MetaNamespaceKeyFunc(new); err == nil {
+				CheckQueue.Add(key)
+			}
+		},
+
+		DeleteFunc: func(obj interface{}) {
+			o, ok := obj.(*icingav1.Check)
+
+			if !ok {
+				tombstone, ok := obj.(cache.DeletedFinalStateUnknown)
+				if !ok {
+					log.Errorf("couldn't get object from tombstone %+v", obj)
+					return
+				}
+				o, ok = tombstone.Obj.(*icingav1.Check)
+				if !ok {
+					log.Errorf("tombstone contained object that is not a Check %+v", obj)
+					return
+				}
+			}
+
+			err := c.CheckDeleted(o)
+
+			if err != nil {
+				log.Errorf("failed to process deletion: %s", err.Error())
+			}
+		},
+	})
+
+	return
+}
+
+func (c *Controller) Start() {
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	go c.KubernetesFactory.Start(stopCh)
+	go c.IcingaFactory.Start(stopCh)
+
+	go c.Run(stopCh)
+
+	sigterm := make(chan os.Signal, 1)
+	signal.Notify(sigterm, syscall.SIGTERM)
+	signal.Notify(sigterm, syscall.SIGINT)
+	<-sigterm
+}
+
+func (c *Controller) Run(stopCh <-chan struct{}) {
+
+	log.Infof("starting controller")
+
+	defer runtime.HandleCrash()
+
+	defer c.PodQueue.ShutDown()
+	defer c.NodeQueue.ShutDown()
+	defer c.NamespaceQueue.ShutDown()
+	defer c.ServiceQueue.ShutDown()
+	defer c.EndpointsQueue.ShutDown()
+	defer c.PersistentVolumeClaimQueue.ShutDown()
+	defer c.ResourceQuotaQueue.ShutDown()
+	defer c.PersistentVolumeQueue.ShutDown()
+	defer c.DeploymentQueue.ShutDown()
+	defer c.DaemonSetQueue.ShutDown()
+	defer c.ReplicaSetQueue.ShutDown()
 	defer c.StatefulSetQueue.ShutDown()
+	defer c.ValidatingWebhookConfigurationQueue.ShutDown()
+	defer c.MutatingWebhookConfigurationQueue.ShutDown()
+	defer c.JobQueue.ShutDown()
 	defer c.HostGroupQueue.ShutDown()
 	defer c.HostQueue.ShutDown()
 	defer c.CheckQueue.ShutDown()
 
-	if !cache.WaitForCacheSync(stopCh, c.PodSynced, c.NodeSynced, c.NamespaceSynced, c.DeploymentSynced, c.DaemonSetSynced, c.ReplicaSetSynced, c.StatefulSetSynced, c.HostGroupSynced, c.HostSynced, c.CheckSynced) {
+	if !cache.WaitForCacheSync(stopCh, c.PodSynced, c.NodeSynced, c.NamespaceSynced, c.ServiceSynced, c.EndpointsSynced, c.PersistentVolumeClaimSynced, c.ResourceQuotaSynced, c.PersistentVolumeSynced, c.DeploymentSynced, c.DaemonSetSynced, c.ReplicaSetSynced, c.StatefulSetSynced, c.ValidatingWebhookConfigurationSynced, c.MutatingWebhookConfigurationSynced, c.JobSynced, c.HostGroupSynced, c.HostSynced, c.CheckSynced) {
 		runtime.HandleError(fmt.Errorf("Timed out waiting for caches to sync"))
 		return
 	}
@@ -590,6 +1059,16 @@ func (c *Controller) Run(stopCh <-chan struct{}) {
 
 	go wait.Until(c.runNamespaceWorker, time.Second, stopCh)
 
+	go wait.Until(c.runServiceWorker, time.Second, stopCh)
+
+	go wait.Until(c.runEndpointsWorker, time.Second, stopCh)
+
+	go wait.Until(c.runPersistentVolumeClaimWorker, time.Second, stopCh)
+
+	go wait.Until(c.runResourceQuotaWorker, time.Second, stopCh)
+
+	go wait.Until(c.runPersistentVolumeWorker, time.Second, stopCh)
+
 	go wait.Until(c.runDeploymentWorker, time.Second, stopCh)
 
 	go wait.Until(c.runDaemonSetWorker, time.Second, stopCh)
@@ -598,6 +1077,12 @@ func (c *Controller) Run(stopCh <-chan struct{}) {
 
 	go wait.Until(c.runStatefulSetWorker, time.Second, stopCh)
 
+	go wait.Until(c.runValidatingWebhookConfigurationWorker, time.Second, stopCh)
+
+	go wait.Until(c.runMutatingWebhookConfigurationWorker, time.Second, stopCh)
+
+	go wait.Until(c.runJobWorker, time.Second, stopCh)
+
 	go wait.Until(c.runHostGroupWorker, time.Second, stopCh)
 
 	go wait.Until(c.runHostWorker, time.Second, stopCh)
@@ -777,33 +1262,33 @@ func (c *Controller) processNamespace(key string) error {
 
 }
 
-func (c *Controller) runDeploymentWorker() {
-	for c.processNextDeployment() {
+func (c *Controller) runServiceWorker() {
+	for c.processNextService() {
 	}
 }
 
-func (c *Controller) processNextDeployment() bool {
-	obj, shutdown := c.DeploymentQueue.Get()
+func (c *Controller) processNextService() bool {
+	obj, shutdown := c.ServiceQueue.Get()
 	if shutdown {
 		return false
 	}
 
 	err := func(obj interface{}) error {
-		defer c.DeploymentQueue.Done(obj)
+		defer c.ServiceQueue.Done(obj)
 		var key string
 		var ok bool
 
 		if key, ok = obj.(string); !ok {
-			c.DeploymentQueue.Forget(obj)
+			c.ServiceQueue.Forget(obj)
 			runtime.HandleError(fmt.Errorf("expected string in workqueue but got %#v", obj))
 			return nil
 		}
 
-		if err := c.processDeployment(key); err != nil {
+		if err := c.processService(key); err != nil {
 			return fmt.Errorf("error syncing '%s': %s", key, err.Error())
 		}
 
-		c.DeploymentQueue.Forget(obj)
+		c.ServiceQueue.Forget(obj)
 		return nil
 	}(obj)
 
@@ -815,14 +1300,14 @@ func (c *Controller) processNextDeployment() bool {
 	return true
 }
 
-func (c *Controller) processDeployment(key string) error {
+func (c *Controller) processService(key string) error {
 
 	namespace, name, err := cache.SplitMetaNamespaceKey(key)
 	if err != nil {
 		return fmt.Errorf("could not parse name %s: %s", key, err.Error())
 	}
 
-	o, err := c.DeploymentLister.Deployments(namespace).Get(name)
+	o, err := c.ServiceLister.Services(namespace).Get(name)
 	if err != nil {
 		if errors.IsNotFound(err) {
 			return fmt.Errorf("tried to get %s, but it was not found", key)
@@ -831,37 +1316,37 @@ func (c *Controller) processDeployment(key string) error {
 		}
 	}
 
-	return c.DeploymentCreatedOrUpdated(o)
+	return c.ServiceCreatedOrUpdated(o)
 
 }
 
-func (c *Controller) runDaemonSetWorker() {
-	for c.processNextDaemonSet() {
+func (c *Controller) runEndpointsWorker() {
+	for c.processNextEndpoints() {
 	}
 }
 
-func (c *Controller) processNextDaemonSet() bool {
-	obj, shutdown := c.DaemonSetQueue.Get()
+func (c *Controller) processNextEndpoints() bool {
+	obj, shutdown := c.EndpointsQueue.Get()
 	if shutdown {
 		return false
 	}
 
 	err := func(obj interface{}) error {
-		defer c.DaemonSetQueue.Done(obj)
+		defer c.EndpointsQueue.Done(obj)
 		var key string
 		var ok bool
 
 		if key, ok = obj.(string); !ok {
-			c.DaemonSetQueue.Forget(obj)
+			c.EndpointsQueue.Forget(obj)
 			runtime.HandleError(fmt.Errorf("expected string in workqueue but got %#v", obj))
 			return nil
 		}
 
-		if err := c.processDaemonSet(key); err != nil {
+		if err := c.processEndpoints(key); err != nil {
 			return fmt.Errorf("error syncing '%s': %s", key, err.Error())
 		}
 
-		c.DaemonSetQueue.Forget(obj)
+		c.EndpointsQueue.Forget(obj)
 		return nil
 	}(obj)
 
@@ -873,14 +1358,14 @@ func (c *Controller) processNextDaemonSet() bool {
 	return true
 }
 
-func (c *Controller) processDaemonSet(key string) error {
+func (c *Controller) processEndpoints(key string) error {
 
 	namespace, name, err := cache.SplitMetaNamespaceKey(key)
 	if err != nil {
 		return fmt.Errorf("could not parse name %s: %s", key, err.Error())
 	}
 
-	o, err := c.DaemonSetLister.DaemonSets(namespace).Get(name)
+	o, err := c.EndpointsLister.Endpoints(namespace).Get(name)
 	if err != nil {
 		if errors.IsNotFound(err) {
 			return fmt.Errorf("tried to get %s, but it was not found", key)
@@ -889,37 +1374,37 @@ func (c *Controller) processDaemonSet(key string) error {
 		}
 	}
 
-	return c.DaemonSetCreatedOrUpdated(o)
+	return c.EndpointsCreatedOrUpdated(o)
 
 }
 
-func (c *Controller) runReplicaSetWorker() {
-	for c.processNextReplicaSet() {
+func (c *Controller) runPersistentVolumeClaimWorker() {
+	for c.processNextPersistentVolumeClaim() {
 	}
 }
 
-func (c *Controller) processNextReplicaSet() bool {
-	obj, shutdown := c.ReplicaSetQueue.Get()
+func (c *Controller) processNextPersistentVolumeClaim() bool {
+	obj, shutdown := c.PersistentVolumeClaimQueue.Get()
 	if shutdown {
 		return false
 	}
 
 	err := func(obj interface{}) error {
-		defer c.ReplicaSetQueue.Done(obj)
+		defer c.PersistentVolumeClaimQueue.Done(obj)
 		var key string
 		var ok bool
 
 		if key, ok = obj.(string); !ok {
-			c.ReplicaSetQueue.Forget(obj)
+			c.PersistentVolumeClaimQueue.Forget(obj)
 			runtime.HandleError(fmt.Errorf("expected string in workqueue but got %#v", obj))
 			return nil
 		}
 
-		if err := c.processReplicaSet(key); err != nil {
+		if err := c.processPersistentVolumeClaim(key); err != nil {
 			return fmt.Errorf("error syncing '%s': %s", key, err.Error())
 		}
 
-		c.ReplicaSetQueue.Forget(obj)
+		c.PersistentVolumeClaimQueue.Forget(obj)
 		return nil
 	}(obj)
 
@@ -931,14 +1416,14 @@ func (c *Controller) processNextReplicaSet() bool {
 	return true
 }
 
-func (c *Controller) processReplicaSet(key string) error {
+func (c *Controller) processPersistentVolumeClaim(key string) error {
 
 	namespace, name, err := cache.SplitMetaNamespaceKey(key)
 	if err != nil {
 		return fmt.Errorf("could not parse name %s: %s", key, err.Error())
 	}
 
-	o, err := c.ReplicaSetLister.ReplicaSets(namespace).Get(name)
+	o, err := c.PersistentVolumeClaimLister.PersistentVolumeClaims(namespace).Get(name)
 	if err != nil {
 		if errors.IsNotFound(err) {
 			return fmt.Errorf("tried to get %s, but it was not found", key)
@@ -947,37 +1432,37 @@ func (c *Controller) processReplicaSet(key string) error {
 		}
 	}
 
-	return c.ReplicaSetCreatedOrUpdated(o)
+	return c.PersistentVolumeClaimCreatedOrUpdated(o)
 
 }
 
-func (c *Controller) runStatefulSetWorker() {
-	for c.processNextStatefulSet() {
+func (c *Controller) runResourceQuotaWorker() {
+	for c.processNextResourceQuota() {
 	}
 }
 
-func (c *Controller) processNextStatefulSet() bool {
-	obj, shutdown := c.StatefulSetQueue.Get()
+func (c *Controller) processNextResourceQuota() bool {
+	obj, shutdown := c.ResourceQuotaQueue.Get()
 	if shutdown {
 		return false
 	}
 
 	err := func(obj interface{}) error {
-		defer c.StatefulSetQueue.Done(obj)
+		defer c.ResourceQuotaQueue.Done(obj)
 		var key string
 		var ok bool
 
 		if key, ok = obj.(string); !ok {
-			c.StatefulSetQueue.Forget(obj)
+			c.ResourceQuotaQueue.Forget(obj)
 			runtime.HandleError(fmt.Errorf("expected string in workqueue but got %#v", obj))
 			return nil
 		}
 
-		if err := c.processStatefulSet(key); err != nil {
+		if err := c.processResourceQuota(key); err != nil {
 			return fmt.Errorf("error syncing '%s': %s", key, err.Error())
 		}
 
-		c.StatefulSetQueue.Forget(obj)
+		c.ResourceQuotaQueue.Forget(obj)
 		return nil
 	}(obj)
 
@@ -989,14 +1474,14 @@ func (c *Controller) processNextStatefulSet() bool {
 	return true
 }
 
-func (c *Controller) processStatefulSet(key string) error {
+func (c *Controller) processResourceQuota(key string) error {
 
 	namespace, name, err := cache.SplitMetaNamespaceKey(key)
 	if err != nil {
 		return fmt.Errorf("could not parse name %s: %s", key, err.Error())
 	}
 
-	o, err := c.StatefulSetLister.StatefulSets(namespace).Get(name)
+	o, err := c.ResourceQuotaLister.ResourceQuotas(namespace).Get(name)
 	if err != nil {
 		if errors.IsNotFound(err) {
 			return fmt.Errorf("tried to get %s, but it was not found", key)
@@ -1005,7 +1490,462 @@ func (c *Controller) processStatefulSet(key string) error {
 		}
 	}
 
-	return c.StatefulSetCreatedOrUpdated(o)
+	return c.ResourceQuotaCreatedOrUpdated(o)
+
+}
+
+func (c *Controller) runPersistentVolumeWorker() {
+	for c.processNextPersistentVolume() {
+	}
+}
+
+func (c *Controller) processNextPersistentVolume() bool {
+	obj, shutdown := c.PersistentVolumeQueue.Get()
+	if shutdown {
+		return false
+	}
+
+	err := func(obj interface{}) error {
+		defer c.PersistentVolumeQueue.Done(obj)
+		var key string
+		var ok bool
+
+		if key, ok = obj.(string); !ok {
+			c.PersistentVolumeQueue.Forget(obj)
+			runtime.HandleError(fmt.Errorf("expected string in workqueue but got %#v", obj))
+			return nil
+		}
+
+		if err := c.processPersistentVolume(key); err != nil {
+			return fmt.Errorf("error syncing '%s': %s", key, err.Error())
+		}
+
+		c.PersistentVolumeQueue.Forget(obj)
+		return nil
+	}(obj)
+
+	if err != nil {
+		runtime.HandleError(err)
+		return true
+	}
+
+	return true
+}
+
+func (c *Controller) processPersistentVolume(key string) error {
+
+	name := key
+
+	o, err := c.PersistentVolumeLister.Get(name)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return fmt.Errorf("tried to get %s, but it was not found", key)
+		} else {
+			return fmt.Errorf("error getting %s from cache: %s", key, err.Error())
+		}
+	}
+
+	return c.PersistentVolumeCreatedOrUpdated(o)
+
+}
+
+func (c *Controller) runDeploymentWorker() {
+	for c.processNextDeployment() {
+	}
+}
+
+func (c *Controller) processNextDeployment() bool {
+	obj, shutdown := c.DeploymentQueue.Get()
+	if shutdown {
+		return false
+	}
+
+	err := func(obj interface{}) error {
+		defer c.DeploymentQueue.Done(obj)
+		var key string
+		var ok bool
+
+		if key, ok = obj.(string); !ok {
+			c.DeploymentQueue.Forget(obj)
+			runtime.HandleError(fmt.Errorf("expected string in workqueue but got %#v", obj))
+			return nil
+		}
+
+		if err := c.processDeployment(key); err != nil {
+			return fmt.Errorf("error syncing '%s': %s", key, err.Error())
+		}
+
+		c.DeploymentQueue.Forget(obj)
+		return nil
+	}(obj)
+
+	if err != nil {
+		runtime.HandleError(err)
+		return true
+	}
+
+	return true
+}
+
+func (c *Controller) processDeployment(key string) error {
+
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		return fmt.Errorf("could not parse name %s: %s", key, err.Error())
+	}
+
+	o, err := c.DeploymentLister.Deployments(namespace).Get(name)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return fmt.Errorf("tried to get %s, but it was not found", key)
+		} else {
+			return fmt.Errorf("error getting %s from cache: %s", key, err.Error())
+		}
+	}
+
+	return c.DeploymentCreatedOrUpdated(o)
+
+}
+
+func (c *Controller) runDaemonSetWorker() {
+	for c.processNextDaemonSet() {
+	}
+}
+
+func (c *Controller) processNextDaemonSet() bool {
+	obj, shutdown := c.DaemonSetQueue.Get()
+	if shutdown {
+		return false
+	}
+
+	err := func(obj interface{}) error {
+		defer c.DaemonSetQueue.Done(obj)
+		var key string
+		var ok bool
+
+		if key, ok = obj.(string); !ok {
+			c.DaemonSetQueue.Forget(obj)
+			runtime.HandleError(fmt.Errorf("expected string in workqueue but got %#v", obj))
+			return nil
+		}
+
+		if err := c.processDaemonSet(key); err != nil {
+			return fmt.Errorf("error syncing '%s': %s", key, err.Error())
+		}
+
+		c.DaemonSetQueue.Forget(obj)
+		return nil
+	}(obj)
+
+	if err != nil {
+		runtime.HandleError(err)
+		return true
+	}
+
+	return true
+}
+
+func (c *Controller) processDaemonSet(key string) error {
+
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		return fmt.Errorf("could not parse name %s: %s", key, err.Error())
+	}
+
+	o, err := c.DaemonSetLister.DaemonSets(namespace).Get(name)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return fmt.Errorf("tried to get %s, but it was not found", key)
+		} else {
+			return fmt.Errorf("error getting %s from cache: %s", key, err.Error())
+		}
+	}
+
+	return c.DaemonSetCreatedOrUpdated(o)
+
+}
+
+func (c *Controller) runReplicaSetWorker() {
+	for c.processNextReplicaSet() {
+	}
+}
+
+func (c *Controller) processNextReplicaSet() bool {
+	obj, shutdown := c.ReplicaSetQueue.Get()
+	if shutdown {
+		return false
+	}
+
+	err := func(obj interface{}) error {
+		defer c.ReplicaSetQueue.Done(obj)
+		var key string
+		var ok bool
+
+		if key, ok = obj.(string); !ok {
+			c.ReplicaSetQueue.Forget(obj)
+			runtime.HandleError(fmt.Errorf("expected string in workqueue but got %#v", obj))
+			return nil
+		}
+
+		if err := c.processReplicaSet(key); err != nil {
+			return fmt.Errorf("error syncing '%s': %s", key, err.Error())
+		}
+
+		c.ReplicaSetQueue.Forget(obj)
+		return nil
+	}(obj)
+
+	if err != nil {
+		runtime.HandleError(err)
+		return true
+	}
+
+	return true
+}
+
+func (c *Controller) processReplicaSet(key string) error {
+
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		return fmt.Errorf("could not parse name %s: %s", key, err.Error())
+	}
+
+	o, err := c.ReplicaSetLister.ReplicaSets(namespace).Get(name)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return fmt.Errorf("tried to get %s, but it was not found", key)
+		} else {
+			return fmt.Errorf("error getting %s from cache: %s", key, err.Error())
+		}
+	}
+
+	return c.ReplicaSetCreatedOrUpdated(o)
+
+}
+
+func (c *Controller) runStatefulSetWorker() {
+	for c.processNextStatefulSet() {
+	}
+}
+
+func (c *Controller) processNextStatefulSet() bool {
+	obj, shutdown := c.StatefulSetQueue.Get()
+	if shutdown {
+		return false
+	}
+
+	err := func(obj interface{}) error {
+		defer c.StatefulSetQueue.Done(obj)
+		var key string
+		var ok bool
+
+		if key, ok = obj.(string); !ok {
+			c.StatefulSetQueue.Forget(obj)
+			runtime.HandleError(fmt.Errorf("expected string in workqueue but got %#v", obj))
+			return nil
+		}
+
+		if err := c.processStatefulSet(key); err != nil {
+			return fmt.Errorf("error syncing '%s': %s", key, err.Error())
+		}
+
+		c.StatefulSetQueue.Forget(obj)
+		return nil
+	}(obj)
+
+	if err != nil {
+		runtime.HandleError(err)
+		return true
+	}
+
+	return true
+}
+
+func (c *Controller) processStatefulSet(key string) error {
+
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		return fmt.Errorf("could not parse name %s: %s", key, err.Error())
+	}
+
+	o, err := c.StatefulSetLister.StatefulSets(namespace).Get(name)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return fmt.Errorf("tried to get %s, but it was not found", key)
+		} else {
+			return fmt.Errorf("error getting %s from cache: %s", key, err.Error())
+		}
+	}
+
+	return c.StatefulSetCreatedOrUpdated(o)
+
+}
+
+func (c *Controller) runValidatingWebhookConfigurationWorker() {
+	for c.processNextValidatingWebhookConfiguration() {
+	}
+}
+
+func (c *Controller) processNextValidatingWebhookConfiguration() bool {
+	obj, shutdown := c.ValidatingWebhookConfigurationQueue.Get()
+	if shutdown {
+		return false
+	}
+
+	err := func(obj interface{}) error {
+		defer c.ValidatingWebhookConfigurationQueue.Done(obj)
+		var key string
+		var ok bool
+
+		if key, ok = obj.(string); !ok {
+			c.ValidatingWebhookConfigurationQueue.Forget(obj)
+			runtime.HandleError(fmt.Errorf("expected string in workqueue but got %#v", obj))
+			return nil
+		}
+
+		if err := c.processValidatingWebhookConfiguration(key); err != nil {
+			return fmt.Errorf("error syncing '%s': %s", key, err.Error())
+		}
+
+		c.ValidatingWebhookConfigurationQueue.Forget(obj)
+		return nil
+	}(obj)
+
+	if err != nil {
+		runtime.HandleError(err)
+		return true
+	}
+
+	return true
+}
+
+func (c *Controller) processValidatingWebhookConfiguration(key string) error {
+
+	name := key
+
+	o, err := c.ValidatingWebhookConfigurationLister.Get(name)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return fmt.Errorf("tried to get %s, but it was not found", key)
+		} else {
+			return fmt.Errorf("error getting %s from cache: %s", key, err.Error())
+		}
+	}
+
+	return c.ValidatingWebhookConfigurationCreatedOrUpdated(o)
+
+}
+
+func (c *Controller) runMutatingWebhookConfigurationWorker() {
+	for c.processNextMutatingWebhookConfiguration() {
+	}
+}
+
+func (c *Controller) processNextMutatingWebhookConfiguration() bool {
+	obj, shutdown := c.MutatingWebhookConfigurationQueue.Get()
+	if shutdown {
+		return false
+	}
+
+	err := func(obj interface{}) error {
+		defer c.MutatingWebhookConfigurationQueue.Done(obj)
+		var key string
+		var ok bool
+
+		if key, ok = obj.(string); !ok {
+			c.MutatingWebhookConfigurationQueue.Forget(obj)
+			runtime.HandleError(fmt.Errorf("expected string in workqueue but got %#v", obj))
+			return nil
+		}
+
+		if err := c.processMutatingWebhookConfiguration(key); err != nil {
+			return fmt.Errorf("error syncing '%s': %s", key, err.Error())
+		}
+
+		c.MutatingWebhookConfigurationQueue.Forget(obj)
+		return nil
+	}(obj)
+
+	if err != nil {
+		runtime.HandleError(err)
+		return true
+	}
+
+	return true
+}
+
+func (c *Controller) processMutatingWebhookConfiguration(key string) error {
+
+	name := key
+
+	o, err := c.MutatingWebhookConfigurationLister.Get(name)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return fmt.Errorf("tried to get %s, but it was not found", key)
+		} else {
+			return fmt.Errorf("error getting %s from cache: %s", key, err.Error())
+		}
+	}
+
+	return c.MutatingWebhookConfigurationCreatedOrUpdated(o)
+
+}
+
+func (c *Controller) runJobWorker() {
+	for c.processNextJob() {
+	}
+}
+
+func (c *Controller) processNextJob() bool {
+	obj, shutdown := c.JobQueue.Get()
+	if shutdown {
+		return false
+	}
+
+	err := func(obj interface{}) error {
+		defer c.JobQueue.Done(obj)
+		var key string
+		var ok bool
+
+		if key, ok = obj.(string); !ok {
+			c.JobQueue.Forget(obj)
+			runtime.HandleError(fmt.Errorf("expected string in workqueue but got %#v", obj))
+			return nil
+		}
+
+		if err := c.processJob(key); err != nil {
+			return fmt.Errorf("error syncing '%s': %s", key, err.Error())
+		}
+
+		c.JobQueue.Forget(obj)
+		return nil
+	}(obj)
+
+	if err != nil {
+		runtime.HandleError(err)
+		return true
+	}
+
+	return true
+}
+
+func (c *Controller) processJob(key string) error {
+
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		return fmt.Errorf("could not parse name %s: %s", key, err.Error())
+	}
+
+	o, err := c.JobLister.Jobs(namespace).Get(name)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return fmt.Errorf("tried to get %s, but it was not found", key)
+		} else {
+			return fmt.Errorf("error getting %s from cache: %s", key, err.Error())
+		}
+	}
+
+	return c.JobCreatedOrUpdated(o)
 
 }
 
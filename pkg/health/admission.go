@@ -0,0 +1,69 @@
+package health
+
+import (
+	"fmt"
+	"strings"
+
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// A webhook of a ValidatingWebhookConfiguration or MutatingWebhookConfiguration.
+type Webhook struct {
+	Name          string
+	FailurePolicy *admissionregistrationv1.FailurePolicyType
+	ClientConfig  admissionregistrationv1.WebhookClientConfig
+}
+
+func ValidatingWebhookConfiguration(wc *admissionregistrationv1.ValidatingWebhookConfiguration, eps map[string]*corev1.Endpoints) Result {
+	var webhooks []Webhook
+	for _, w := range wc.Webhooks {
+		webhooks = append(webhooks, Webhook{Name: w.Name, FailurePolicy: w.FailurePolicy, ClientConfig: w.ClientConfig})
+	}
+	return Webhooks("validatingwebhookconfiguration", wc.Name, webhooks, eps)
+}
+
+func MutatingWebhookConfiguration(wc *admissionregistrationv1.MutatingWebhookConfiguration, eps map[string]*corev1.Endpoints) Result {
+	var webhooks []Webhook
+	for _, w := range wc.Webhooks {
+		webhooks = append(webhooks, Webhook{Name: w.Name, FailurePolicy: w.FailurePolicy, ClientConfig: w.ClientConfig})
+	}
+	return Webhooks("mutatingwebhookconfiguration", wc.Name, webhooks, eps)
+}
+
+// Critical if a webhook that fails requests when it cannot be called points to a Service without ready endpoints,
+// as it blocks all requests it intercepts. Warning if a webhook that ignores failures does. eps are the Endpoints of
+// the Services the webhooks point to, by namespace/name. Webhooks with a URL are not checked.
+func Webhooks(typ, name string, webhooks []Webhook, eps map[string]*corev1.Endpoints) Result {
+	r := Result{State: OK}
+	var problems []string
+
+	for _, w := range webhooks {
+		svc := w.ClientConfig.Service
+		if svc == nil {
+			continue
+		}
+
+		key := svc.Namespace + "/" + svc.Name
+		if ready, _ := endpoints(eps[key]); ready > 0 {
+			continue
+		}
+
+		// Webhooks fail closed unless configured otherwise.
+		state, policy := Critical, admissionregistrationv1.Fail
+		if w.FailurePolicy != nil && *w.FailurePolicy == admissionregistrationv1.Ignore {
+			state, policy = Warning, admissionregistrationv1.Ignore
+		}
+
+		r.State = Worst(r.State, state)
+		problems = append(problems, fmt.Sprintf("webhook %s: service %s has no ready endpoints (failurePolicy %s)", w.Name, key, policy))
+	}
+
+	if len(problems) > 0 {
+		r.Output = fmt.Sprintf("%s %s: %s", typ, name, strings.Join(problems, ", "))
+	} else {
+		r.Output = fmt.Sprintf("%s %s: %d webhooks available", typ, name, len(webhooks))
+	}
+
+	return r
+}
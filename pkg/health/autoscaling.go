@@ -0,0 +1,56 @@
+package health
+
+import (
+	"fmt"
+	"time"
+
+	autoscalingv2beta2 "k8s.io/api/autoscaling/v2beta2"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// Critical if a HorizontalPodAutoscaler cannot compute the replicas (for example because metrics are unavailable),
+// warning if it cannot scale or if it has been at its maximum replicas for longer than grace.
+func HorizontalPodAutoscaler(hpa *autoscalingv2beta2.HorizontalPodAutoscaler, grace time.Duration) Result {
+	min := int32(1)
+	if hpa.Spec.MinReplicas != nil {
+		min = *hpa.Spec.MinReplicas
+	}
+	max := hpa.Spec.MaxReplicas
+
+	r := Result{
+		State: OK,
+		Output: fmt.Sprintf("horizontalpodautoscaler %s: %d current, %d desired replicas (min %d, max %d)",
+			hpa.Name, hpa.Status.CurrentReplicas, hpa.Status.DesiredReplicas, min, max),
+		PerfData: []string{
+			perf("current", int64(hpa.Status.CurrentReplicas), int64(min), int64(max)),
+			perf("desired", int64(hpa.Status.DesiredReplicas), int64(min), int64(max)),
+		},
+	}
+
+	if hpa.Status.CurrentReplicas >= max {
+		since := hpa.CreationTimestamp.Time
+		if hpa.Status.LastScaleTime != nil {
+			since = hpa.Status.LastScaleTime.Time
+		}
+		if time.Since(since) > grace {
+			r.State = Warning
+			r.Output += ", at maximum since " + since.UTC().Format(time.RFC3339)
+		}
+	}
+
+	for _, c := range hpa.Status.Conditions {
+		if c.Status != corev1.ConditionFalse {
+			continue
+		}
+		switch c.Type {
+		case autoscalingv2beta2.ScalingActive:
+			r.State = Critical
+			r.Output += fmt.Sprintf(", scaling is not active: %s: %s", c.Reason, c.Message)
+		case autoscalingv2beta2.AbleToScale:
+			r.State = Worst(r.State, Warning)
+			r.Output += fmt.Sprintf(", not able to scale: %s: %s", c.Reason, c.Message)
+		}
+	}
+
+	return r
+}
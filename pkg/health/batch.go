@@ -0,0 +1,145 @@
+package health
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/robfig/cron"
+
+	batchv1 "k8s.io/api/batch/v1"
+	batchv1beta1 "k8s.io/api/batch/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// How long after a scheduled time the CronJob controller may start a Job before we consider the schedule missed,
+// unless the CronJob has a larger starting deadline.
+const scheduleSlack = 5 * time.Minute
+
+// OK while running or when complete, warning if pods failed and the Job is retrying, critical if the Job failed.
+func Job(job *batchv1.Job) Result {
+	r := Result{
+		Output: fmt.Sprintf("job %s: %d active, %d succeeded, %d failed", job.Name, job.Status.Active, job.Status.Succeeded, job.Status.Failed),
+		PerfData: []string{
+			perf("active", int64(job.Status.Active), 0, -1),
+			perf("succeeded", int64(job.Status.Succeeded), 0, -1),
+			perf("failed", int64(job.Status.Failed), 0, -1),
+		},
+	}
+
+	switch failed, message := jobFailed(job); {
+	case failed:
+		r.State = Critical
+		if message != "" {
+			r.Output += ": " + message
+		}
+	case jobComplete(job):
+		r.State = OK
+		r.Output += ", complete"
+	case job.Status.Failed > 0:
+		r.State = Warning
+	default:
+		r.State = OK
+	}
+
+	return r
+}
+
+// Critical if the last Job of the CronJob failed or if no Job succeeded within the last windowRuns scheduled
+// intervals, warning if a scheduled run did not happen. Suspended CronJobs are OK. jobs are the Jobs owned
+// by the CronJob.
+func CronJob(cj *batchv1beta1.CronJob, jobs []*batchv1.Job, windowRuns int) Result {
+	if cj.Spec.Suspend != nil && *cj.Spec.Suspend {
+		return Result{State: OK, Output: fmt.Sprintf("cronjob %s is suspended", cj.Name)}
+	}
+
+	schedule, err := cron.ParseStandard(cj.Spec.Schedule)
+	if err != nil {
+		return Result{State: Unknown, Output: fmt.Sprintf("cronjob %s: cannot parse schedule '%s': %s", cj.Name, cj.Spec.Schedule, err.Error())}
+	}
+
+	// batch/v1beta1 CronJobs have no time zone. The CronJob controller schedules them in the time zone of the
+	// controller manager, which is UTC on practically every cluster, so we evaluate the schedule in UTC as well.
+	// The schedule returns times in the location of the time it is given.
+	now := time.Now().UTC()
+
+	var last *batchv1.Job
+	lastSuccess := cj.CreationTimestamp.Time
+	succeeded := false
+	for _, job := range jobs {
+		if last == nil || job.CreationTimestamp.After(last.CreationTimestamp.Time) {
+			last = job
+		}
+		if jobComplete(job) && job.Status.CompletionTime != nil && job.Status.CompletionTime.After(lastSuccess) {
+			lastSuccess = job.Status.CompletionTime.Time
+			succeeded = true
+		}
+	}
+
+	r := Result{
+		State:    OK,
+		Output:   fmt.Sprintf("cronjob %s", cj.Name),
+		PerfData: []string{perf("active", int64(len(cj.Status.Active)), 0, -1)},
+	}
+
+	if last == nil {
+		r.Output += ": no jobs"
+	} else if failed, message := jobFailed(last); failed {
+		r.State = Critical
+		r.Output += fmt.Sprintf(": last job %s failed", last.Name)
+		if message != "" {
+			r.Output += ": " + message
+		}
+	} else if jobComplete(last) {
+		r.Output += fmt.Sprintf(": last job %s complete", last.Name)
+	} else {
+		r.Output += fmt.Sprintf(": last job %s is running", last.Name)
+	}
+
+	// The interval between two runs at the current time; irregular schedules are judged by the next interval.
+	next := schedule.Next(now)
+	window := schedule.Next(next).Sub(next) * time.Duration(windowRuns)
+
+	if windowRuns > 0 && now.Sub(lastSuccess) > window {
+		r.State = Critical
+		if succeeded {
+			r.Output += ", no successful run since " + lastSuccess.UTC().Format(time.RFC3339)
+		} else {
+			r.Output += ", no successful run yet"
+		}
+	}
+
+	slack := scheduleSlack
+	if d := cj.Spec.StartingDeadlineSeconds; d != nil && time.Duration(*d)*time.Second > slack {
+		slack = time.Duration(*d) * time.Second
+	}
+
+	lastSchedule := cj.CreationTimestamp.UTC()
+	if cj.Status.LastScheduleTime != nil {
+		lastSchedule = cj.Status.LastScheduleTime.UTC()
+	}
+
+	if missed := schedule.Next(lastSchedule); now.After(missed.Add(slack)) {
+		r.State = Worst(r.State, Warning)
+		r.Output += ", missed schedule at " + missed.UTC().Format(time.RFC3339)
+	}
+
+	return r
+}
+
+func jobComplete(job *batchv1.Job) bool {
+	for _, c := range job.Status.Conditions {
+		if c.Type == batchv1.JobComplete && c.Status == corev1.ConditionTrue {
+			return true
+		}
+	}
+	return false
+}
+
+func jobFailed(job *batchv1.Job) (bool, string) {
+	for _, c := range job.Status.Conditions {
+		if c.Type == batchv1.JobFailed && c.Status == corev1.ConditionTrue {
+			return true, c.Message
+		}
+	}
+	return false, ""
+}
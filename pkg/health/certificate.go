@@ -0,0 +1,86 @@
+package health
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// Critical if the certificate chain of a TLS Secret cannot be parsed or if a certificate in it expires in less than
+// critical days, warning if it expires in less than warning days.
+func TLSSecret(secret *corev1.Secret, warning, critical int) Result {
+	chain, err := certificates(secret.Data[corev1.TLSCertKey])
+	if err != nil {
+		return Result{State: Critical, Output: fmt.Sprintf("secret %s: %s", secret.Name, err.Error())}
+	}
+
+	leaf := chain[0]
+
+	r := Result{Output: fmt.Sprintf("secret %s: certificate %s", secret.Name, leaf.Subject.String())}
+	if len(leaf.DNSNames) > 0 {
+		r.Output += " (" + strings.Join(leaf.DNSNames, ", ") + ")"
+	}
+
+	// The certificate of the chain that expires first decides.
+	expiring := leaf
+	for _, cert := range chain[1:] {
+		if cert.NotAfter.Before(expiring.NotAfter) {
+			expiring = cert
+		}
+	}
+
+	days := int(time.Until(expiring.NotAfter).Hours() / 24)
+
+	if expiring != leaf {
+		r.Output += fmt.Sprintf(", issuer %s", expiring.Subject.String())
+	}
+	if time.Now().After(expiring.NotAfter) {
+		r.Output += " expired at " + expiring.NotAfter.UTC().Format(time.RFC3339)
+	} else {
+		r.Output += fmt.Sprintf(" expires at %s, in %d days", expiring.NotAfter.UTC().Format(time.RFC3339), days)
+	}
+
+	r.PerfData = []string{fmt.Sprintf("days=%d;%d:;%d:", days, warning, critical)}
+
+	switch {
+	case days < critical || time.Now().After(expiring.NotAfter):
+		r.State = Critical
+	case days < warning:
+		r.State = Warning
+	default:
+		r.State = OK
+	}
+
+	return r
+}
+
+// Parse a PEM encoded certificate chain.
+func certificates(data []byte) ([]*x509.Certificate, error) {
+	var chain []*x509.Certificate
+
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("cannot parse certificate: %s", err.Error())
+		}
+		chain = append(chain, cert)
+	}
+
+	if len(chain) == 0 {
+		return nil, fmt.Errorf("no certificate in %s", corev1.TLSCertKey)
+	}
+
+	return chain, nil
+}
@@ -0,0 +1,70 @@
+package health
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// Diagnose the containers of the pods of a workload: critical if a container is in CrashLoopBackOff or cannot pull
+// its image, warning if a container was OOMKilled or restarted at least maxRestarts times within the window or if a pod
+// has been Pending for longer than pendingGrace. restarts are the restarts within the window, by pod UID and
+// container name as "uid/name".
+func Containers(pods []*corev1.Pod, restarts map[string]int32, maxRestarts int, window, pendingGrace time.Duration) Result {
+	pods = append([]*corev1.Pod(nil), pods...)
+	sort.Slice(pods, func(i, j int) bool { return pods[i].Name < pods[j].Name })
+
+	r := Result{State: OK}
+	var problems []string
+	var total int64
+
+	problem := func(state State, format string, a ...interface{}) {
+		r.State = Worst(r.State, state)
+		problems = append(problems, fmt.Sprintf(format, a...))
+	}
+
+	for _, pod := range pods {
+		if pod.Status.Phase == corev1.PodPending && time.Since(pod.CreationTimestamp.Time) > pendingGrace {
+			problem(Warning, "%s Pending since %s", pod.Name, pod.CreationTimestamp.UTC().Format(time.RFC3339))
+		}
+
+		statuses := append(append([]corev1.ContainerStatus(nil), pod.Status.InitContainerStatuses...), pod.Status.ContainerStatuses...)
+
+		for _, cs := range statuses {
+			if w := cs.State.Waiting; w != nil {
+				switch w.Reason {
+				case "CrashLoopBackOff":
+					problem(Critical, "%s/%s CrashLoopBackOff", pod.Name, cs.Name)
+				case "ImagePullBackOff", "ErrImagePull", "InvalidImageName":
+					problem(Critical, "%s/%s %s (%s)", pod.Name, cs.Name, w.Reason, cs.Image)
+				}
+			}
+
+			if t := cs.LastTerminationState.Terminated; t != nil && t.Reason == "OOMKilled" && time.Since(t.FinishedAt.Time) <= window {
+				problem(Warning, "%s/%s OOMKilled at %s", pod.Name, cs.Name, t.FinishedAt.UTC().Format(time.RFC3339))
+			}
+
+			n := restarts[string(pod.UID)+"/"+cs.Name]
+			total += int64(n)
+			if maxRestarts > 0 && int(n) >= maxRestarts {
+				problem(Warning, "%s/%s restarted %d times in %s", pod.Name, cs.Name, n, window)
+			}
+		}
+	}
+
+	if len(problems) > 0 {
+		r.Output = strings.Join(problems, ", ")
+	} else {
+		r.Output = fmt.Sprintf("no problems with the containers of %d pods", len(pods))
+	}
+
+	r.PerfData = []string{
+		perf("pods", int64(len(pods)), 0, -1),
+		perf("restarts", total, 0, -1),
+	}
+
+	return r
+}
@@ -0,0 +1,65 @@
+package health
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// An object that is being deleted.
+type Deletion struct {
+	Kind       string
+	Namespace  string
+	Name       string
+	Since      time.Time
+	Finalizers []string
+}
+
+// Warning if objects have been deleted for longer than grace, but still exist, usually because a finalizer was not
+// removed. Critical if one of them is a namespace.
+func StuckDeletions(deletions []Deletion, grace time.Duration) Result {
+	var stuck []Deletion
+	for _, d := range deletions {
+		if time.Since(d.Since) > grace {
+			stuck = append(stuck, d)
+		}
+	}
+
+	sort.Slice(stuck, func(i, j int) bool { return stuck[i].Since.Before(stuck[j].Since) })
+
+	r := Result{
+		State:    OK,
+		Output:   "no objects stuck in deletion",
+		PerfData: []string{perf("stuck", int64(len(stuck)), 0, -1)},
+	}
+
+	if len(stuck) == 0 {
+		return r
+	}
+
+	var objects []string
+	for _, d := range stuck {
+		name := d.Name
+		if d.Namespace != "" {
+			name = d.Namespace + "/" + d.Name
+		}
+
+		o := fmt.Sprintf("%s %s since %s", strings.ToLower(d.Kind), name, d.Since.UTC().Format(time.RFC3339))
+		if len(d.Finalizers) > 0 {
+			o += " (finalizers: " + strings.Join(d.Finalizers, ", ") + ")"
+		} else {
+			o += " (no finalizers)"
+		}
+		objects = append(objects, o)
+
+		if d.Kind == "Namespace" {
+			r.State = Critical
+		} else {
+			r.State = Worst(r.State, Warning)
+		}
+	}
+
+	r.Output = fmt.Sprintf("%d objects stuck in deletion: %s", len(stuck), strings.Join(objects, ", "))
+	return r
+}
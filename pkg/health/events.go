@@ -0,0 +1,121 @@
+package health
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// How many reasons and objects the output of Events lists.
+const eventsListed = 5
+
+// Warning if a namespace had warning or more Warning events since since, critical if it had critical or more (0 to
+// disable). Aggregated events count with the number of times they occurred if they first occurred since since.
+// Otherwise, we don't know how many of the occurrences fall into the window and count only the last one.
+func Events(namespace string, events []*corev1.Event, since time.Time, warning, critical int) Result {
+	reasons := make(map[string]int)
+	objects := make(map[string]int)
+	total := 0
+
+	for _, e := range events {
+		if e.Type != corev1.EventTypeWarning || eventLastSeen(e).Before(since) {
+			continue
+		}
+
+		count := int(e.Count)
+		if e.Series != nil {
+			count = int(e.Series.Count)
+		}
+		if count < 1 || eventFirstSeen(e).Before(since) {
+			count = 1
+		}
+
+		reasons[e.Reason] += count
+		objects[strings.ToLower(e.InvolvedObject.Kind)+"/"+e.InvolvedObject.Name] += count
+		total += count
+	}
+
+	r := Result{
+		State:    OK,
+		Output:   fmt.Sprintf("namespace %s: no warning events", namespace),
+		PerfData: []string{fmt.Sprintf("events=%d;%s;%s;0", total, threshold(warning), threshold(critical))},
+	}
+
+	for _, reason := range top(reasons, len(reasons)) {
+		r.PerfData = append(r.PerfData, perf(reason, int64(reasons[reason]), 0, -1))
+	}
+
+	if total == 0 {
+		return r
+	}
+
+	r.Output = fmt.Sprintf("namespace %s: %d warning events, reasons: %s, objects: %s", namespace, total,
+		counted(reasons, top(reasons, eventsListed)), counted(objects, top(objects, eventsListed)))
+
+	switch {
+	case critical > 0 && total >= critical:
+		r.State = Critical
+	case warning > 0 && total >= warning:
+		r.State = Warning
+	}
+
+	return r
+}
+
+// When an event occurred last.
+func eventLastSeen(e *corev1.Event) time.Time {
+	if e.Series != nil {
+		return e.Series.LastObservedTime.Time
+	}
+	if !e.LastTimestamp.IsZero() {
+		return e.LastTimestamp.Time
+	}
+	return e.EventTime.Time
+}
+
+// When an event occurred first, zero if we don't know.
+func eventFirstSeen(e *corev1.Event) time.Time {
+	if e.Series == nil && !e.FirstTimestamp.IsZero() {
+		return e.FirstTimestamp.Time
+	}
+	return e.EventTime.Time
+}
+
+// The n keys with the highest counts, by name if they are equal.
+func top(counts map[string]int, n int) []string {
+	var keys []string
+	for k := range counts {
+		keys = append(keys, k)
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		if counts[keys[i]] != counts[keys[j]] {
+			return counts[keys[i]] > counts[keys[j]]
+		}
+		return keys[i] < keys[j]
+	})
+
+	if len(keys) > n {
+		keys = keys[:n]
+	}
+	return keys
+}
+
+func counted(counts map[string]int, keys []string) string {
+	var s []string
+	for _, k := range keys {
+		s = append(s, fmt.Sprintf("%s (%d)", k, counts[k]))
+	}
+	return strings.Join(s, ", ")
+}
+
+// A perfdata threshold, empty if disabled.
+func threshold(t int) string {
+	if t <= 0 {
+		return ""
+	}
+	return fmt.Sprintf("%d", t)
+}
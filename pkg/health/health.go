@@ -0,0 +1,104 @@
+// Package health evaluates the state of Kubernetes objects with the same semantics as check_kubernetes.
+package health
+
+import (
+	"fmt"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// A Nagios plugin state.
+type State int
+
+const (
+	OK       State = 0
+	Warning  State = 1
+	Critical State = 2
+	Unknown  State = 3
+)
+
+func (s State) String() string {
+	switch s {
+	case OK:
+		return "OK"
+	case Warning:
+		return "WARNING"
+	case Critical:
+		return "CRITICAL"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+// The result of evaluating the health of an object: state, plugin output and performance data.
+type Result struct {
+	State    State
+	Output   string
+	PerfData []string
+}
+
+// Evaluate the health of a Pod, Deployment, DaemonSet, ReplicaSet, StatefulSet, Job, Node,
+// PersistentVolume or ComponentStatus.
+// Other objects are evaluated by their Ready condition if they are unstructured.
+func Evaluate(o interface{}) Result {
+	switch o := o.(type) {
+	case *corev1.Pod:
+		return Pod(o)
+	case *appsv1.Deployment:
+		return Deployment(o)
+	case *appsv1.DaemonSet:
+		return DaemonSet(o)
+	case *appsv1.ReplicaSet:
+		return ReplicaSet(o)
+	case *appsv1.StatefulSet:
+		return StatefulSet(o)
+	case *batchv1.Job:
+		return Job(o)
+	case *corev1.Node:
+		return Node(o)
+	case *corev1.PersistentVolume:
+		return PersistentVolume(o)
+	case *corev1.ComponentStatus:
+		return ComponentStatus(o)
+	case *unstructured.Unstructured:
+		return Unstructured(strings.ToLower(o.GetKind()), o, Rule{})
+	default:
+		return Result{State: Unknown, Output: fmt.Sprintf("cannot evaluate objects of type %T", o)}
+	}
+}
+
+// The worse of two states. Unknown is only worse than OK.
+func Worst(a, b State) State {
+	rank := func(s State) int {
+		switch s {
+		case OK:
+			return 0
+		case Unknown:
+			return 1
+		case Warning:
+			return 2
+		default:
+			return 3
+		}
+	}
+	if rank(b) > rank(a) {
+		return b
+	}
+	return a
+}
+
+// Format a performance data item. min and max are omitted if negative.
+func perf(label string, value, min, max int64) string {
+	p := fmt.Sprintf("%s=%d;;;", label, value)
+	if min >= 0 {
+		p += fmt.Sprintf("%d", min)
+	}
+	if max >= 0 {
+		p += fmt.Sprintf(";%d", max)
+	}
+	return p
+}
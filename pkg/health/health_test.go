@@ -0,0 +1,1184 @@
+package health
+
+import (
+	"compress/gzip"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2beta2 "k8s.io/api/autoscaling/v2beta2"
+	batchv1 "k8s.io/api/batch/v1"
+	batchv1beta1 "k8s.io/api/batch/v1beta1"
+	coordinationv1 "k8s.io/api/coordination/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1beta1 "k8s.io/api/networking/v1beta1"
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func int32p(i int32) *int32 {
+	return &i
+}
+
+func deployment(replicas *int32, ready, available int32) *appsv1.Deployment {
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "mydeploy", Namespace: "default"},
+		Spec:       appsv1.DeploymentSpec{Replicas: replicas},
+		Status:     appsv1.DeploymentStatus{ReadyReplicas: ready, AvailableReplicas: available},
+	}
+}
+
+func pod(phase corev1.PodPhase, ready ...bool) *corev1.Pod {
+	p := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "mypod", Namespace: "default"},
+		Status:     corev1.PodStatus{Phase: phase},
+	}
+	for _, r := range ready {
+		p.Status.ContainerStatuses = append(p.Status.ContainerStatuses, corev1.ContainerStatus{Ready: r, RestartCount: 2})
+	}
+	return p
+}
+
+func node(conditions ...corev1.NodeCondition) *corev1.Node {
+	return &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "node1"},
+		Status:     corev1.NodeStatus{Conditions: conditions},
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	tests := []struct {
+		name   string
+		object interface{}
+		state  State
+		output string
+	}{
+		{"deployment available", deployment(int32p(3), 3, 3), OK, "deployment mydeploy: 3 of 3 replicas available, 3 ready"},
+		{"deployment partially available", deployment(int32p(3), 2, 1), Warning, "deployment mydeploy: 1 of 3 replicas available, 2 ready"},
+		{"deployment unavailable", deployment(int32p(3), 0, 0), Critical, "deployment mydeploy: 0 of 3 replicas available, 0 ready"},
+		{"deployment scaled to zero", deployment(int32p(0), 0, 0), OK, "deployment mydeploy: 0 of 0 replicas available, 0 ready"},
+		{"deployment default replicas", deployment(nil, 0, 0), Critical, "deployment mydeploy: 0 of 1 replicas available, 0 ready"},
+		{
+			"daemonset partially available",
+			&appsv1.DaemonSet{
+				ObjectMeta: metav1.ObjectMeta{Name: "myds"},
+				Status:     appsv1.DaemonSetStatus{DesiredNumberScheduled: 5, NumberReady: 4, NumberAvailable: 4},
+			},
+			Warning,
+			"daemonset myds: 4 of 5 replicas available, 4 ready",
+		},
+		{
+			"replicaset available",
+			&appsv1.ReplicaSet{
+				ObjectMeta: metav1.ObjectMeta{Name: "myrs"},
+				Spec:       appsv1.ReplicaSetSpec{Replicas: int32p(2)},
+				Status:     appsv1.ReplicaSetStatus{ReadyReplicas: 2, AvailableReplicas: 2},
+			},
+			OK,
+			"replicaset myrs: 2 of 2 replicas available, 2 ready",
+		},
+		{
+			"statefulset not ready",
+			&appsv1.StatefulSet{
+				ObjectMeta: metav1.ObjectMeta{Name: "mystate"},
+				Spec:       appsv1.StatefulSetSpec{Replicas: int32p(3)},
+				Status:     appsv1.StatefulSetStatus{ReadyReplicas: 0, CurrentReplicas: 3},
+			},
+			Critical,
+			"statefulset mystate: 0 of 3 replicas available, 0 ready",
+		},
+		{"pod running", pod(corev1.PodRunning, true, true), OK, "pod mypod is Running, 2 of 2 containers ready"},
+		{"pod not ready", pod(corev1.PodRunning, true, false), Warning, "pod mypod is Running, 1 of 2 containers ready"},
+		{"pod pending", pod(corev1.PodPending), Warning, "pod mypod is Pending, 0 of 0 containers ready"},
+		{"pod failed", pod(corev1.PodFailed, false), Critical, "pod mypod is Failed, 0 of 1 containers ready"},
+		{"pod succeeded", pod(corev1.PodSucceeded, false), OK, "pod mypod is Succeeded, 0 of 1 containers ready"},
+		{"pod unknown", pod(corev1.PodUnknown), Unknown, "pod mypod is Unknown, 0 of 0 containers ready"},
+		{
+			"node ready",
+			node(corev1.NodeCondition{Type: corev1.NodeReady, Status: corev1.ConditionTrue},
+				corev1.NodeCondition{Type: corev1.NodeMemoryPressure, Status: corev1.ConditionFalse}),
+			OK,
+			"node node1 is ready",
+		},
+		{
+			"node under pressure",
+			node(corev1.NodeCondition{Type: corev1.NodeReady, Status: corev1.ConditionTrue},
+				corev1.NodeCondition{Type: corev1.NodeDiskPressure, Status: corev1.ConditionTrue}),
+			Warning,
+			"node node1 is ready, conditions: DiskPressure",
+		},
+		{
+			"node not ready",
+			node(corev1.NodeCondition{Type: corev1.NodeReady, Status: corev1.ConditionUnknown, Message: "Kubelet stopped posting node status."},
+				corev1.NodeCondition{Type: corev1.NodeMemoryPressure, Status: corev1.ConditionTrue}),
+			Critical,
+			"node node1 is not ready: Kubelet stopped posting node status., conditions: MemoryPressure",
+		},
+		{"node without conditions", node(), Critical, "node node1 has not reported its state"},
+		{
+			"componentstatus healthy",
+			&corev1.ComponentStatus{
+				ObjectMeta: metav1.ObjectMeta{Name: "etcd-0"},
+				Conditions: []corev1.ComponentCondition{{Type: corev1.ComponentHealthy, Status: corev1.ConditionTrue}},
+			},
+			OK,
+			"etcd-0 is healthy",
+		},
+		{
+			"componentstatus unhealthy",
+			&corev1.ComponentStatus{
+				ObjectMeta: metav1.ObjectMeta{Name: "scheduler"},
+				Conditions: []corev1.ComponentCondition{{Type: corev1.ComponentHealthy, Status: corev1.ConditionFalse, Error: "connection refused"}},
+			},
+			Critical,
+			"scheduler is unhealthy: connection refused",
+		},
+		{"unsupported object", &corev1.Service{}, Unknown, "cannot evaluate objects of type *v1.Service"},
+	}
+
+	for _, test := range tests {
+		r := Evaluate(test.object)
+		assert.Equal(t, test.state, r.State, test.name)
+		assert.Equal(t, test.output, r.Output, test.name)
+	}
+}
+
+func TestReplicasPerfData(t *testing.T) {
+	r := Evaluate(deployment(int32p(3), 2, 1))
+	assert.Equal(t, []string{"desired=3;;;0", "ready=2;;;0;3", "available=1;;;0;3"}, r.PerfData)
+}
+
+func TestPodPerfData(t *testing.T) {
+	r := Evaluate(pod(corev1.PodRunning, true, false))
+	assert.Equal(t, []string{"containers=2;;;0", "ready=1;;;0;2", "restarts=4;;;0"}, r.PerfData)
+}
+
+func TestWorst(t *testing.T) {
+	assert.Equal(t, OK, Worst(OK, OK))
+	assert.Equal(t, Unknown, Worst(OK, Unknown))
+	assert.Equal(t, Warning, Worst(Unknown, Warning))
+	assert.Equal(t, Critical, Worst(Critical, Warning))
+	assert.Equal(t, Critical, Worst(Unknown, Critical))
+}
+
+func TestStateString(t *testing.T) {
+	assert.Equal(t, "OK", OK.String())
+	assert.Equal(t, "WARNING", Warning.String())
+	assert.Equal(t, "CRITICAL", Critical.String())
+	assert.Equal(t, "UNKNOWN", Unknown.String())
+}
+
+func TestUnstructured(t *testing.T) {
+	certificate := func(status string) *unstructured.Unstructured {
+		return &unstructured.Unstructured{Object: map[string]interface{}{
+			"metadata": map[string]interface{}{"name": "mycert"},
+			"status": map[string]interface{}{
+				"conditions": []interface{}{
+					map[string]interface{}{"type": "Issuing", "status": "False"},
+					map[string]interface{}{"type": "Ready", "status": status, "message": "Certificate is up to date"},
+				},
+			},
+		}}
+	}
+
+	rollout := func(phase string) *unstructured.Unstructured {
+		o := &unstructured.Unstructured{Object: map[string]interface{}{
+			"metadata": map[string]interface{}{"name": "myrollout"},
+		}}
+		if phase != "" {
+			unstructured.SetNestedField(o.Object, phase, "status", "phase")
+		}
+		return o
+	}
+	phaseRule := Rule{Path: "status.phase", Healthy: []string{"Healthy"}, Warning: []string{"Progressing", "Paused"}}
+
+	tests := []struct {
+		name   string
+		typ    string
+		object *unstructured.Unstructured
+		rule   Rule
+		state  State
+		output string
+	}{
+		{"condition true", "certificate", certificate("True"), Rule{Condition: "Ready"}, OK, "certificate mycert: Ready is True: Certificate is up to date"},
+		{"condition false", "certificate", certificate("False"), Rule{Condition: "Ready"}, Critical, "certificate mycert: Ready is False: Certificate is up to date"},
+		{"condition unknown", "certificate", certificate("Unknown"), Rule{Condition: "Ready"}, Unknown, "certificate mycert: Ready is Unknown: Certificate is up to date"},
+		{"default condition", "certificate", certificate("True"), Rule{}, OK, "certificate mycert: Ready is True: Certificate is up to date"},
+		{"missing condition", "certificate", certificate("True"), Rule{Condition: "Synced"}, Unknown, "certificate mycert has no condition Synced"},
+		{"path healthy", "rollout", rollout("Healthy"), phaseRule, OK, "rollout myrollout: status.phase is Healthy"},
+		{"path warning", "rollout", rollout("Paused"), phaseRule, Warning, "rollout myrollout: status.phase is Paused"},
+		{"path critical", "rollout", rollout("Degraded"), phaseRule, Critical, "rollout myrollout: status.phase is Degraded"},
+		{"path missing", "rollout", rollout(""), phaseRule, Unknown, "rollout myrollout: status.phase is not set"},
+	}
+
+	for _, test := range tests {
+		r := Unstructured(test.typ, test.object, test.rule)
+		assert.Equal(t, test.state, r.State, test.name)
+		assert.Equal(t, test.output, r.Output, test.name)
+	}
+}
+
+func TestService(t *testing.T) {
+	selector := map[string]string{"app": "myapp"}
+	old := metav1.NewTime(time.Now().Add(-time.Hour))
+
+	service := func(typ corev1.ServiceType, selector map[string]string, ingress ...corev1.LoadBalancerIngress) *corev1.Service {
+		return &corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: "mysvc", CreationTimestamp: old},
+			Spec:       corev1.ServiceSpec{Type: typ, Selector: selector},
+			Status:     corev1.ServiceStatus{LoadBalancer: corev1.LoadBalancerStatus{Ingress: ingress}},
+		}
+	}
+
+	endpoints := func(ready, notReady int) *corev1.Endpoints {
+		ep := &corev1.Endpoints{ObjectMeta: metav1.ObjectMeta{Name: "mysvc"}}
+		// The same addresses in two subsets, for two ports.
+		for s := 0; s < 2; s++ {
+			var subset corev1.EndpointSubset
+			for i := 0; i < ready; i++ {
+				subset.Addresses = append(subset.Addresses, corev1.EndpointAddress{IP: fmt.Sprintf("10.0.0.%d", i)})
+			}
+			for i := 0; i < notReady; i++ {
+				subset.NotReadyAddresses = append(subset.NotReadyAddresses, corev1.EndpointAddress{IP: fmt.Sprintf("10.0.1.%d", i)})
+			}
+			ep.Subsets = append(ep.Subsets, subset)
+		}
+		return ep
+	}
+
+	tests := []struct {
+		name    string
+		service *corev1.Service
+		ep      *corev1.Endpoints
+		state   State
+		output  string
+	}{
+		{"all ready", service(corev1.ServiceTypeClusterIP, selector), endpoints(2, 0), OK, "service mysvc has 2 ready and 0 not ready endpoints"},
+		{"some ready", service(corev1.ServiceTypeClusterIP, selector), endpoints(1, 1), Warning, "service mysvc has 1 ready and 1 not ready endpoints"},
+		{"none ready", service(corev1.ServiceTypeClusterIP, selector), endpoints(0, 2), Critical, "service mysvc has 0 ready and 2 not ready endpoints"},
+		{"selector matches nothing", service(corev1.ServiceTypeClusterIP, selector), endpoints(0, 0), Critical, "service mysvc has 0 ready and 0 not ready endpoints, the selector matches no pods"},
+		{"no endpoints object", service(corev1.ServiceTypeClusterIP, selector), nil, Critical, "service mysvc has 0 ready and 0 not ready endpoints, the selector matches no pods"},
+		{"no selector", service(corev1.ServiceTypeClusterIP, nil), nil, OK, "service mysvc has no selector"},
+		{
+			"external name",
+			&corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "mysvc"}, Spec: corev1.ServiceSpec{Type: corev1.ServiceTypeExternalName, ExternalName: "example.com"}},
+			nil,
+			OK,
+			"service mysvc points to example.com",
+		},
+		{
+			"load balancer",
+			service(corev1.ServiceTypeLoadBalancer, selector, corev1.LoadBalancerIngress{IP: "192.0.2.1"}),
+			endpoints(1, 0),
+			OK,
+			"service mysvc has 1 ready and 0 not ready endpoints, load balancer 192.0.2.1",
+		},
+		{
+			"load balancer without ingress",
+			service(corev1.ServiceTypeLoadBalancer, selector),
+			endpoints(1, 0),
+			Critical,
+			"service mysvc has 1 ready and 0 not ready endpoints, no load balancer ingress",
+		},
+	}
+
+	for _, test := range tests {
+		r := Service(test.service, test.ep, 10*time.Minute)
+		assert.Equal(t, test.state, r.State, test.name)
+		assert.Equal(t, test.output, r.Output, test.name)
+	}
+
+	// A new load balancer gets some time for its ingress.
+	svc := service(corev1.ServiceTypeLoadBalancer, selector)
+	svc.CreationTimestamp = metav1.Now()
+	r := Service(svc, endpoints(1, 0), 10*time.Minute)
+	assert.Equal(t, OK, r.State)
+	assert.Equal(t, "service mysvc has 1 ready and 0 not ready endpoints, waiting for load balancer ingress", r.Output)
+	assert.Equal(t, []string{"ready=1;;;0", "notready=0;;;0"}, r.PerfData)
+}
+
+func TestIngress(t *testing.T) {
+	old := metav1.NewTime(time.Now().Add(-time.Hour))
+
+	ingress := func(address string, backends ...string) *networkingv1beta1.Ingress {
+		ing := &networkingv1beta1.Ingress{
+			ObjectMeta: metav1.ObjectMeta{Name: "mying", CreationTimestamp: old},
+			Spec: networkingv1beta1.IngressSpec{
+				Backend: &networkingv1beta1.IngressBackend{ServiceName: "default-backend"},
+			},
+		}
+		if address != "" {
+			ing.Status.LoadBalancer.Ingress = []corev1.LoadBalancerIngress{{IP: address}}
+		}
+		rule := networkingv1beta1.IngressRule{Host: "www.example.com"}
+		rule.HTTP = &networkingv1beta1.HTTPIngressRuleValue{}
+		for _, b := range backends {
+			rule.HTTP.Paths = append(rule.HTTP.Paths, networkingv1beta1.HTTPIngressPath{
+				Path:    "/" + b,
+				Backend: networkingv1beta1.IngressBackend{ServiceName: b},
+			})
+		}
+		ing.Spec.Rules = append(ing.Spec.Rules, rule)
+		return ing
+	}
+
+	services := map[string]bool{"default-backend": true, "web": true, "api": true}
+
+	tests := []struct {
+		name    string
+		ingress *networkingv1beta1.Ingress
+		state   State
+		output  string
+	}{
+		{"healthy", ingress("192.0.2.1", "web", "api"), OK, "ingress mying has address 192.0.2.1"},
+		{"missing backend", ingress("192.0.2.1", "web", "gone", "alsogone"), Critical, "ingress mying has address 192.0.2.1, missing backend services: alsogone, gone"},
+		{"no address", ingress("", "web"), Critical, "ingress mying has no load balancer address"},
+	}
+
+	for _, test := range tests {
+		r := Ingress(test.ingress, services, 10*time.Minute)
+		assert.Equal(t, test.state, r.State, test.name)
+		assert.Equal(t, test.output, r.Output, test.name)
+	}
+
+	ing := ingress("", "web")
+	ing.CreationTimestamp = metav1.Now()
+	r := Ingress(ing, services, 10*time.Minute)
+	assert.Equal(t, OK, r.State)
+	assert.Equal(t, "ingress mying is waiting for a load balancer address", r.Output)
+
+	assert.Equal(t, []string{"api", "default-backend", "web"}, IngressBackends(ingress("", "web", "api", "web")))
+}
+
+func job(name string, created time.Time, condition batchv1.JobConditionType, message string) *batchv1.Job {
+	j := &batchv1.Job{ObjectMeta: metav1.ObjectMeta{Name: name, CreationTimestamp: metav1.NewTime(created)}}
+	if condition != "" {
+		j.Status.Conditions = []batchv1.JobCondition{{Type: condition, Status: corev1.ConditionTrue, Message: message}}
+	}
+	if condition == batchv1.JobComplete {
+		completed := metav1.NewTime(created.Add(time.Minute))
+		j.Status.CompletionTime = &completed
+		j.Status.Succeeded = 1
+	}
+	return j
+}
+
+func TestJob(t *testing.T) {
+	now := time.Now()
+
+	running := job("myjob", now, "", "")
+	running.Status.Active = 1
+
+	retrying := job("myjob", now, "", "")
+	retrying.Status.Active = 1
+	retrying.Status.Failed = 2
+
+	failed := job("myjob", now, batchv1.JobFailed, "Job has reached the specified backoff limit")
+	failed.Status.Failed = 6
+
+	tests := []struct {
+		name   string
+		job    *batchv1.Job
+		state  State
+		output string
+	}{
+		{"running", running, OK, "job myjob: 1 active, 0 succeeded, 0 failed"},
+		{"retrying", retrying, Warning, "job myjob: 1 active, 0 succeeded, 2 failed"},
+		{"complete", job("myjob", now, batchv1.JobComplete, ""), OK, "job myjob: 0 active, 1 succeeded, 0 failed, complete"},
+		{"failed", failed, Critical, "job myjob: 0 active, 0 succeeded, 6 failed: Job has reached the specified backoff limit"},
+	}
+
+	for _, test := range tests {
+		r := Evaluate(test.job)
+		assert.Equal(t, test.state, r.State, test.name)
+		assert.Equal(t, test.output, r.Output, test.name)
+	}
+
+	assert.Equal(t, []string{"active=1;;;0", "succeeded=0;;;0", "failed=2;;;0"}, Job(retrying).PerfData)
+}
+
+func TestCronJob(t *testing.T) {
+	now := time.Now()
+
+	cronjob := func(created, lastSchedule time.Time) *batchv1beta1.CronJob {
+		scheduled := metav1.NewTime(lastSchedule)
+		return &batchv1beta1.CronJob{
+			ObjectMeta: metav1.ObjectMeta{Name: "mycj", CreationTimestamp: metav1.NewTime(created)},
+			Spec:       batchv1beta1.CronJobSpec{Schedule: "0 * * * *"},
+			Status:     batchv1beta1.CronJobStatus{LastScheduleTime: &scheduled},
+		}
+	}
+
+	healthy := cronjob(now.Add(-5*time.Hour), now.Add(-time.Minute))
+	missed := cronjob(now.Add(-5*time.Hour), now.Add(-3*time.Hour))
+	suspended := cronjob(now.Add(-5*time.Hour), now.Add(-3*time.Hour))
+	suspended.Spec.Suspend = new(bool)
+	*suspended.Spec.Suspend = true
+	invalid := cronjob(now, now)
+	invalid.Spec.Schedule = "every hour"
+
+	tests := []struct {
+		name    string
+		cronjob *batchv1beta1.CronJob
+		jobs    []*batchv1.Job
+		state   State
+		output  string
+	}{
+		{
+			"last job complete",
+			healthy,
+			[]*batchv1.Job{
+				job("mycj-1", now.Add(-2*time.Hour), batchv1.JobFailed, ""),
+				job("mycj-2", now.Add(-time.Hour), batchv1.JobComplete, ""),
+			},
+			OK,
+			"cronjob mycj: last job mycj-2 complete",
+		},
+		{
+			"last job running",
+			healthy,
+			[]*batchv1.Job{
+				job("mycj-1", now.Add(-time.Hour), batchv1.JobComplete, ""),
+				job("mycj-2", now.Add(-time.Minute), "", ""),
+			},
+			OK,
+			"cronjob mycj: last job mycj-2 is running",
+		},
+		{
+			"last job failed",
+			healthy,
+			[]*batchv1.Job{
+				job("mycj-1", now.Add(-time.Hour), batchv1.JobComplete, ""),
+				job("mycj-2", now.Add(-time.Minute), batchv1.JobFailed, "BackoffLimitExceeded"),
+			},
+			Critical,
+			"cronjob mycj: last job mycj-2 failed: BackoffLimitExceeded",
+		},
+		{
+			"no success within the window",
+			healthy,
+			[]*batchv1.Job{
+				job("mycj-1", now.Add(-4*time.Hour), batchv1.JobComplete, ""),
+				job("mycj-2", now.Add(-time.Minute), "", ""),
+			},
+			Critical,
+			"cronjob mycj: last job mycj-2 is running, no successful run since " + now.Add(-4*time.Hour+time.Minute).UTC().Format(time.RFC3339),
+		},
+		{"never succeeded", healthy, nil, Critical, "cronjob mycj: no jobs, no successful run yet"},
+		{
+			"missed schedule",
+			missed,
+			[]*batchv1.Job{job("mycj-1", now.Add(-time.Hour), batchv1.JobComplete, "")},
+			Warning,
+			"cronjob mycj: last job mycj-1 complete, missed schedule at ",
+		},
+		{"suspended", suspended, nil, OK, "cronjob mycj is suspended"},
+		{"invalid schedule", invalid, nil, Unknown, "cronjob mycj: cannot parse schedule 'every hour'"},
+	}
+
+	for _, test := range tests {
+		r := CronJob(test.cronjob, test.jobs, 2)
+		assert.Equal(t, test.state, r.State, test.name)
+		assert.Contains(t, r.Output, test.output, test.name)
+	}
+
+	// New CronJobs are not critical before their first runs.
+	r := CronJob(cronjob(now.Add(-10*time.Minute), now.Add(-time.Minute)), nil, 2)
+	assert.Equal(t, OK, r.State)
+	assert.Equal(t, "cronjob mycj: no jobs", r.Output)
+}
+
+// Schedules are in UTC, whatever the local time zone of the controller is.
+func TestCronJobScheduleUTC(t *testing.T) {
+	local := time.Local
+	defer func() { time.Local = local }()
+	time.Local = time.FixedZone("UTC-5", -5*3600)
+
+	// A daily run that happened six hours ago. In UTC-5, the next run would have been an hour ago.
+	ran := time.Now().UTC().Add(-6 * time.Hour).Truncate(time.Minute)
+	scheduled := metav1.NewTime(ran.In(time.Local))
+	cj := &batchv1beta1.CronJob{
+		ObjectMeta: metav1.ObjectMeta{Name: "mycj", CreationTimestamp: metav1.NewTime(ran.Add(-48 * time.Hour))},
+		Spec:       batchv1beta1.CronJobSpec{Schedule: fmt.Sprintf("%d %d * * *", ran.Minute(), ran.Hour())},
+		Status:     batchv1beta1.CronJobStatus{LastScheduleTime: &scheduled},
+	}
+
+	r := CronJob(cj, nil, 0)
+	assert.Equal(t, OK, r.State)
+	assert.Equal(t, "cronjob mycj: no jobs", r.Output)
+}
+
+func TestPersistentVolumeClaim(t *testing.T) {
+	old := metav1.NewTime(time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC))
+
+	pvc := func(phase corev1.PersistentVolumeClaimPhase, created metav1.Time) *corev1.PersistentVolumeClaim {
+		p := &corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{Name: "data", CreationTimestamp: created},
+			Status:     corev1.PersistentVolumeClaimStatus{Phase: phase},
+		}
+		if phase != corev1.ClaimPending {
+			p.Spec.VolumeName = "pv-1"
+		}
+		if phase == corev1.ClaimBound {
+			p.Status.Capacity = corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("10Gi")}
+		}
+		return p
+	}
+
+	tests := []struct {
+		name   string
+		pvc    *corev1.PersistentVolumeClaim
+		state  State
+		output string
+	}{
+		{"bound", pvc(corev1.ClaimBound, old), OK, "persistentvolumeclaim data is Bound to volume pv-1 (10Gi)"},
+		{"pending", pvc(corev1.ClaimPending, metav1.Now()), OK, "persistentvolumeclaim data is Pending"},
+		{"pending too long", pvc(corev1.ClaimPending, old), Critical, "persistentvolumeclaim data is Pending since 2019-01-01T00:00:00Z"},
+		{"lost", pvc(corev1.ClaimLost, old), Critical, "persistentvolumeclaim data is Lost, volume pv-1 no longer exists"},
+	}
+
+	for _, test := range tests {
+		r := PersistentVolumeClaim(test.pvc, 5*time.Minute)
+		assert.Equal(t, test.state, r.State, test.name)
+		assert.Equal(t, test.output, r.Output, test.name)
+	}
+}
+
+func TestPersistentVolume(t *testing.T) {
+	pv := func(phase corev1.PersistentVolumePhase, message string) *corev1.PersistentVolume {
+		return &corev1.PersistentVolume{
+			ObjectMeta: metav1.ObjectMeta{Name: "pv-1"},
+			Spec:       corev1.PersistentVolumeSpec{ClaimRef: &corev1.ObjectReference{Namespace: "default", Name: "data"}},
+			Status:     corev1.PersistentVolumeStatus{Phase: phase, Message: message},
+		}
+	}
+
+	tests := []struct {
+		name   string
+		pv     *corev1.PersistentVolume
+		state  State
+		output string
+	}{
+		{"available", pv(corev1.VolumeAvailable, ""), OK, "persistentvolume pv-1 is Available"},
+		{"bound", pv(corev1.VolumeBound, ""), OK, "persistentvolume pv-1 is Bound (claim default/data)"},
+		{"released", pv(corev1.VolumeReleased, ""), Warning, "persistentvolume pv-1 is Released (claim default/data)"},
+		{"failed", pv(corev1.VolumeFailed, "error deleting volume"), Critical, "persistentvolume pv-1 is Failed (claim default/data): error deleting volume"},
+	}
+
+	for _, test := range tests {
+		r := Evaluate(test.pv)
+		assert.Equal(t, test.state, r.State, test.name)
+		assert.Equal(t, test.output, r.Output, test.name)
+	}
+}
+
+func TestUsage(t *testing.T) {
+	gi := uint64(1024 * 1024 * 1024)
+
+	tests := []struct {
+		name     string
+		used     uint64
+		capacity uint64
+		state    State
+		output   string
+	}{
+		{"ok", 5 * gi, 10 * gi, OK, "filesystem usage 50.0% (5.0Gi of 10.0Gi)"},
+		{"warning", 8 * gi, 10 * gi, Warning, "filesystem usage 80.0% (8.0Gi of 10.0Gi)"},
+		{"critical", 19 * gi / 2, 10 * gi, Critical, "filesystem usage 95.0% (9.5Gi of 10.0Gi)"},
+		{"unknown capacity", 5 * gi, 0, Unknown, "filesystem usage unknown"},
+		{"small", 512, 1024, OK, "filesystem usage 50.0% (512 of 1.0Ki)"},
+	}
+
+	for _, test := range tests {
+		r := Usage("filesystem", test.used, test.capacity, 80, 90)
+		assert.Equal(t, test.state, r.State, test.name)
+		assert.Equal(t, test.output, r.Output, test.name)
+	}
+
+	assert.Equal(t, []string{"filesystem=512B;819;921;0;1024"}, Usage("filesystem", 512, 1024, 80, 90).PerfData)
+}
+
+func TestHorizontalPodAutoscaler(t *testing.T) {
+	old := metav1.NewTime(time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC))
+
+	hpa := func(current, desired int32, lastScale metav1.Time, conditions ...autoscalingv2beta2.HorizontalPodAutoscalerCondition) *autoscalingv2beta2.HorizontalPodAutoscaler {
+		return &autoscalingv2beta2.HorizontalPodAutoscaler{
+			ObjectMeta: metav1.ObjectMeta{Name: "myhpa", CreationTimestamp: old},
+			Spec:       autoscalingv2beta2.HorizontalPodAutoscalerSpec{MinReplicas: int32p(2), MaxReplicas: 5},
+			Status: autoscalingv2beta2.HorizontalPodAutoscalerStatus{
+				CurrentReplicas: current,
+				DesiredReplicas: desired,
+				LastScaleTime:   &lastScale,
+				Conditions:      conditions,
+			},
+		}
+	}
+
+	inactive := autoscalingv2beta2.HorizontalPodAutoscalerCondition{
+		Type:    autoscalingv2beta2.ScalingActive,
+		Status:  corev1.ConditionFalse,
+		Reason:  "FailedGetResourceMetric",
+		Message: "unable to get metrics for resource cpu",
+	}
+
+	tests := []struct {
+		name   string
+		hpa    *autoscalingv2beta2.HorizontalPodAutoscaler
+		state  State
+		output string
+	}{
+		{"scaling", hpa(3, 4, old), OK, "horizontalpodautoscaler myhpa: 3 current, 4 desired replicas (min 2, max 5)"},
+		{"recently at maximum", hpa(5, 5, metav1.Now()), OK, "horizontalpodautoscaler myhpa: 5 current, 5 desired replicas (min 2, max 5)"},
+		{"at maximum", hpa(5, 5, old), Warning, "horizontalpodautoscaler myhpa: 5 current, 5 desired replicas (min 2, max 5), at maximum since 2019-01-01T00:00:00Z"},
+		{
+			"scaling not active",
+			hpa(3, 3, old, inactive),
+			Critical,
+			"horizontalpodautoscaler myhpa: 3 current, 3 desired replicas (min 2, max 5), scaling is not active: FailedGetResourceMetric: unable to get metrics for resource cpu",
+		},
+	}
+
+	for _, test := range tests {
+		r := HorizontalPodAutoscaler(test.hpa, 30*time.Minute)
+		assert.Equal(t, test.state, r.State, test.name)
+		assert.Equal(t, test.output, r.Output, test.name)
+	}
+
+	assert.Equal(t, []string{"current=3;;;2;5", "desired=4;;;2;5"}, HorizontalPodAutoscaler(hpa(3, 4, old), time.Minute).PerfData)
+}
+
+// A TLS Secret with a chain of self-signed certificates, one for each expiry time. The first one is for
+// www.example.com.
+func tlsSecret(t *testing.T, notAfter ...time.Time) *corev1.Secret {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var chain []byte
+	for i, n := range notAfter {
+		template := &x509.Certificate{
+			SerialNumber: big.NewInt(int64(i + 1)),
+			Subject:      pkix.Name{CommonName: "My CA"},
+			NotBefore:    time.Now().Add(-24 * time.Hour),
+			NotAfter:     n,
+		}
+		if i == 0 {
+			template.Subject.CommonName = "www.example.com"
+			template.DNSNames = []string{"www.example.com", "example.com"}
+		}
+		der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		chain = append(chain, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})...)
+	}
+
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "mycert"},
+		Type:       corev1.SecretTypeTLS,
+		Data:       map[string][]byte{corev1.TLSCertKey: chain},
+	}
+}
+
+func TestTLSSecret(t *testing.T) {
+	day := 24 * time.Hour
+	in := func(d time.Duration) time.Time {
+		// A little more, so the days are not rounded down.
+		return time.Now().Add(d + time.Hour).Truncate(time.Second)
+	}
+
+	tests := []struct {
+		name   string
+		secret *corev1.Secret
+		state  State
+		output string
+	}{
+		{
+			"valid",
+			tlsSecret(t, in(90*day), in(365*day)),
+			OK,
+			"secret mycert: certificate CN=www.example.com (www.example.com, example.com) expires at " + in(90*day).UTC().Format(time.RFC3339) + ", in 90 days",
+		},
+		{
+			"expiring soon",
+			tlsSecret(t, in(20*day)),
+			Warning,
+			"secret mycert: certificate CN=www.example.com (www.example.com, example.com) expires at " + in(20*day).UTC().Format(time.RFC3339) + ", in 20 days",
+		},
+		{
+			"issuer expiring",
+			tlsSecret(t, in(90*day), in(10*day)),
+			Critical,
+			"secret mycert: certificate CN=www.example.com (www.example.com, example.com), issuer CN=My CA expires at " + in(10*day).UTC().Format(time.RFC3339) + ", in 10 days",
+		},
+		{
+			"expired",
+			tlsSecret(t, time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)),
+			Critical,
+			"secret mycert: certificate CN=www.example.com (www.example.com, example.com) expired at 2019-01-01T00:00:00Z",
+		},
+		{
+			"no certificate",
+			&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "mycert"}, Type: corev1.SecretTypeTLS},
+			Critical,
+			"secret mycert: no certificate in tls.crt",
+		},
+	}
+
+	for _, test := range tests {
+		r := TLSSecret(test.secret, 30, 14)
+		assert.Equal(t, test.state, r.State, test.name)
+		assert.Equal(t, test.output, r.Output, test.name)
+	}
+
+	assert.Equal(t, []string{"days=20;30:;14:"}, TLSSecret(tlsSecret(t, in(20*day)), 30, 14).PerfData)
+}
+
+func TestPodDisruptionBudget(t *testing.T) {
+	pdb := func(healthy, required, expected, allowed int32) *policyv1beta1.PodDisruptionBudget {
+		return &policyv1beta1.PodDisruptionBudget{
+			ObjectMeta: metav1.ObjectMeta{Name: "mypdb"},
+			Status: policyv1beta1.PodDisruptionBudgetStatus{
+				CurrentHealthy:        healthy,
+				DesiredHealthy:        required,
+				ExpectedPods:          expected,
+				PodDisruptionsAllowed: allowed,
+			},
+		}
+	}
+
+	old := time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		pdb     *policyv1beta1.PodDisruptionBudget
+		blocked time.Time
+		state   State
+		output  string
+	}{
+		{"disruptions allowed", pdb(3, 2, 3, 1), time.Time{}, OK, "poddisruptionbudget mypdb: 3 of 3 pods healthy, 2 required, 1 disruptions allowed"},
+		{"recently blocked", pdb(2, 2, 3, 0), time.Now(), OK, "poddisruptionbudget mypdb: 2 of 3 pods healthy, 2 required, 0 disruptions allowed"},
+		{"blocked", pdb(2, 2, 3, 0), old, Warning, "poddisruptionbudget mypdb: 2 of 3 pods healthy, 2 required, 0 disruptions allowed since 2019-01-01T00:00:00Z"},
+		{"no pods", pdb(0, 0, 0, 0), old, OK, "poddisruptionbudget mypdb: 0 of 0 pods healthy, 0 required, 0 disruptions allowed"},
+		{"unhealthy", pdb(1, 2, 3, 0), old, Critical, "poddisruptionbudget mypdb: 1 of 3 pods healthy, 2 required, 0 disruptions allowed"},
+	}
+
+	for _, test := range tests {
+		r := PodDisruptionBudget(test.pdb, test.blocked, time.Hour)
+		assert.Equal(t, test.state, r.State, test.name)
+		assert.Equal(t, test.output, r.Output, test.name)
+	}
+}
+
+func TestResourceQuota(t *testing.T) {
+	rq := func(used string) *corev1.ResourceQuota {
+		return &corev1.ResourceQuota{
+			ObjectMeta: metav1.ObjectMeta{Name: "compute"},
+			Status: corev1.ResourceQuotaStatus{
+				Hard: corev1.ResourceList{
+					corev1.ResourcePods:                  resource.MustParse("10"),
+					corev1.ResourceRequestsCPU:           resource.MustParse("2"),
+					corev1.ResourceServicesLoadBalancers: resource.MustParse("0"),
+				},
+				Used: corev1.ResourceList{
+					corev1.ResourcePods:                  resource.MustParse("4"),
+					corev1.ResourceRequestsCPU:           resource.MustParse(used),
+					corev1.ResourceServicesLoadBalancers: resource.MustParse("0"),
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		name   string
+		rq     *corev1.ResourceQuota
+		state  State
+		output string
+	}{
+		{"below", rq("500m"), OK, "resourcequota compute: 3 resources below 80%"},
+		{"warning", rq("1700m"), Warning, "resourcequota compute: requests.cpu 85.0% (1700m of 2)"},
+		{"critical", rq("2"), Critical, "resourcequota compute: requests.cpu 100.0% (2 of 2)"},
+	}
+
+	for _, test := range tests {
+		r := ResourceQuota(test.rq, 80, 95)
+		assert.Equal(t, test.state, r.State, test.name)
+		assert.Equal(t, test.output, r.Output, test.name)
+	}
+
+	r := ResourceQuota(rq("500m"), 80, 95)
+	assert.Equal(t, []string{
+		"pods=4;8;9.5;0;10",
+		"requests.cpu=0.5;1.6;1.9;0;2",
+		"services.loadbalancers=0;0;0;0;0",
+	}, r.PerfData)
+}
+
+func TestNodeCondition(t *testing.T) {
+	node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-1"}}
+
+	tests := []struct {
+		name   string
+		cond   corev1.NodeCondition
+		state  State
+		output string
+	}{
+		{"ready", corev1.NodeCondition{Type: corev1.NodeReady, Status: corev1.ConditionTrue, Reason: "KubeletReady"}, OK, "node node-1: Ready is True (KubeletReady)"},
+		{"not ready", corev1.NodeCondition{Type: corev1.NodeReady, Status: corev1.ConditionUnknown, Reason: "NodeStatusUnknown", Message: "Kubelet stopped posting node status."}, Critical, "node node-1: Ready is Unknown (NodeStatusUnknown): Kubelet stopped posting node status."},
+		{"no pressure", corev1.NodeCondition{Type: corev1.NodeMemoryPressure, Status: corev1.ConditionFalse}, OK, "node node-1: MemoryPressure is False"},
+		{"pressure", corev1.NodeCondition{Type: corev1.NodeDiskPressure, Status: corev1.ConditionTrue}, Warning, "node node-1: DiskPressure is True"},
+		{"unknown", corev1.NodeCondition{Type: "KernelDeadlock", Status: corev1.ConditionUnknown}, Unknown, "node node-1: KernelDeadlock is Unknown"},
+	}
+
+	for _, test := range tests {
+		r := NodeCondition(node, test.cond)
+		assert.Equal(t, test.state, r.State, test.name)
+		assert.Equal(t, test.output, r.Output, test.name)
+	}
+}
+
+func TestNodeLease(t *testing.T) {
+	node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-1"}}
+
+	lease := func(renewed time.Duration) *coordinationv1.Lease {
+		renewTime := metav1.NewMicroTime(time.Now().Add(-renewed))
+		return &coordinationv1.Lease{Spec: coordinationv1.LeaseSpec{RenewTime: &renewTime}}
+	}
+
+	assert.Equal(t, OK, NodeLease(node, lease(10*time.Second), 40*time.Second).State)
+	assert.Equal(t, Critical, NodeLease(node, lease(time.Minute), 40*time.Second).State)
+	assert.Equal(t, Unknown, NodeLease(node, nil, 40*time.Second).State)
+
+	assert.Equal(t, OK, NodeSchedulable(node).State)
+	node.Spec.Unschedulable = true
+	assert.Equal(t, Warning, NodeSchedulable(node).State)
+}
+
+func TestContainers(t *testing.T) {
+	old := metav1.NewTime(time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC))
+
+	pod := func(name string, phase corev1.PodPhase, statuses ...corev1.ContainerStatus) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, UID: types.UID(name), CreationTimestamp: old},
+			Status:     corev1.PodStatus{Phase: phase, ContainerStatuses: statuses},
+		}
+	}
+
+	running := corev1.ContainerStatus{Name: "app", Ready: true}
+	crashing := corev1.ContainerStatus{Name: "app", State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}}}
+	pulling := corev1.ContainerStatus{Name: "app", Image: "web:1.0", State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "ImagePullBackOff"}}}
+	oomkilled := func(at metav1.Time) corev1.ContainerStatus {
+		return corev1.ContainerStatus{Name: "app", LastTerminationState: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{Reason: "OOMKilled", FinishedAt: at}}}
+	}
+	recent := metav1.NewTime(time.Now().Add(-10 * time.Minute).UTC().Truncate(time.Second))
+
+	tests := []struct {
+		name     string
+		pods     []*corev1.Pod
+		restarts map[string]int32
+		state    State
+		output   string
+	}{
+		{"fine", []*corev1.Pod{pod("web-1", corev1.PodRunning, running)}, map[string]int32{"web-1/app": 1}, OK,
+			"no problems with the containers of 1 pods"},
+		{"restarts", []*corev1.Pod{pod("web-1", corev1.PodRunning, running)}, map[string]int32{"web-1/app": 3}, Warning,
+			"web-1/app restarted 3 times in 1h0m0s"},
+		{"crashloop", []*corev1.Pod{pod("web-2", corev1.PodRunning, crashing), pod("web-1", corev1.PodRunning, oomkilled(recent))}, nil, Critical,
+			"web-1/app OOMKilled at " + recent.Format(time.RFC3339) + ", web-2/app CrashLoopBackOff"},
+		{"old oomkill", []*corev1.Pod{pod("web-1", corev1.PodRunning, oomkilled(old))}, nil, OK,
+			"no problems with the containers of 1 pods"},
+		{"image pull", []*corev1.Pod{pod("web-1", corev1.PodPending, pulling)}, nil, Critical,
+			"web-1 Pending since 2019-01-01T00:00:00Z, web-1/app ImagePullBackOff (web:1.0)"},
+	}
+
+	for _, test := range tests {
+		r := Containers(test.pods, test.restarts, 3, time.Hour, 10*time.Minute)
+		assert.Equal(t, test.state, r.State, test.name)
+		assert.Equal(t, test.output, r.Output, test.name)
+	}
+}
+
+func TestHealthz(t *testing.T) {
+	output := `[+]ping ok
+[+]etcd ok
+[-]informer-sync failed: reason withheld
+[+]poststarthook/start-apiextensions-informers ok
+readyz check failed
+`
+
+	checks := Healthz("readyz", output)
+	if !assert.Len(t, checks, 4) {
+		return
+	}
+
+	assert.Equal(t, "etcd", checks[1].Name)
+	assert.Equal(t, OK, checks[1].Result.State)
+	assert.Equal(t, "informer-sync", checks[2].Name)
+	assert.Equal(t, Critical, checks[2].Result.State)
+	assert.Equal(t, "readyz informer-sync failed: reason withheld", checks[2].Result.Output)
+	assert.Equal(t, "poststarthook/start-apiextensions-informers", checks[3].Name)
+}
+
+func TestValidatingWebhookConfiguration(t *testing.T) {
+	ignore := admissionregistrationv1.Ignore
+
+	wc := &admissionregistrationv1.ValidatingWebhookConfiguration{
+		ObjectMeta: metav1.ObjectMeta{Name: "policies"},
+		Webhooks: []admissionregistrationv1.ValidatingWebhook{{
+			Name:         "validate.policies.example.com",
+			ClientConfig: admissionregistrationv1.WebhookClientConfig{Service: &admissionregistrationv1.ServiceReference{Namespace: "policies", Name: "webhook"}},
+		}, {
+			Name:          "audit.policies.example.com",
+			FailurePolicy: &ignore,
+			ClientConfig:  admissionregistrationv1.WebhookClientConfig{Service: &admissionregistrationv1.ServiceReference{Namespace: "policies", Name: "audit"}},
+		}},
+	}
+
+	ready := &corev1.Endpoints{Subsets: []corev1.EndpointSubset{{Addresses: []corev1.EndpointAddress{{IP: "10.0.0.1"}}}}}
+
+	r := ValidatingWebhookConfiguration(wc, map[string]*corev1.Endpoints{"policies/webhook": ready, "policies/audit": ready})
+	assert.Equal(t, OK, r.State)
+	assert.Equal(t, "validatingwebhookconfiguration policies: 2 webhooks available", r.Output)
+
+	r = ValidatingWebhookConfiguration(wc, map[string]*corev1.Endpoints{"policies/webhook": ready})
+	assert.Equal(t, Warning, r.State)
+	assert.Equal(t, "validatingwebhookconfiguration policies: webhook audit.policies.example.com: service policies/audit has no ready endpoints (failurePolicy Ignore)", r.Output)
+
+	r = ValidatingWebhookConfiguration(wc, map[string]*corev1.Endpoints{"policies/webhook": {}})
+	assert.Equal(t, Critical, r.State)
+	assert.Contains(t, r.Output, "webhook validate.policies.example.com: service policies/webhook has no ready endpoints (failurePolicy Fail)")
+}
+
+func TestStuckDeletions(t *testing.T) {
+	old := time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	pod := Deletion{Kind: "Pod", Namespace: "default", Name: "web-1", Since: old}
+	namespace := Deletion{Kind: "Namespace", Name: "team-a", Since: old.Add(time.Hour), Finalizers: []string{"kubernetes"}}
+	recent := Deletion{Kind: "Pod", Namespace: "default", Name: "web-2", Since: time.Now()}
+
+	r := StuckDeletions([]Deletion{recent}, 10*time.Minute)
+	assert.Equal(t, OK, r.State)
+	assert.Equal(t, "no objects stuck in deletion", r.Output)
+
+	r = StuckDeletions([]Deletion{recent, pod}, 10*time.Minute)
+	assert.Equal(t, Warning, r.State)
+	assert.Equal(t, "1 objects stuck in deletion: pod default/web-1 since 2019-01-01T00:00:00Z (no finalizers)", r.Output)
+
+	r = StuckDeletions([]Deletion{namespace, recent, pod}, 10*time.Minute)
+	assert.Equal(t, Critical, r.State)
+	assert.Equal(t, "2 objects stuck in deletion: pod default/web-1 since 2019-01-01T00:00:00Z (no finalizers), "+
+		"namespace team-a since 2019-01-01T01:00:00Z (finalizers: kubernetes)", r.Output)
+	assert.Equal(t, []string{"stuck=2;;;0"}, r.PerfData)
+}
+
+func TestEvents(t *testing.T) {
+	now := time.Now()
+
+	event := func(typ, reason, kind, name string, count int32, first, last time.Time) *corev1.Event {
+		return &corev1.Event{
+			Type:           typ,
+			Reason:         reason,
+			InvolvedObject: corev1.ObjectReference{Kind: kind, Name: name},
+			Count:          count,
+			FirstTimestamp: metav1.NewTime(first),
+			LastTimestamp:  metav1.NewTime(last),
+		}
+	}
+
+	events := []*corev1.Event{
+		event(corev1.EventTypeWarning, "BackOff", "Pod", "web-1", 7, now.Add(-10*time.Minute), now.Add(-time.Minute)),
+		event(corev1.EventTypeWarning, "FailedMount", "Pod", "db-0", 2, now.Add(-2*time.Minute), now.Add(-2*time.Minute)),
+		// Occurred before the window, only the last occurrence counts.
+		event(corev1.EventTypeWarning, "FailedScheduling", "Pod", "db-1", 50, now.Add(-2*time.Hour), now.Add(-5*time.Minute)),
+		event(corev1.EventTypeWarning, "Unhealthy", "Pod", "web-1", 1, now.Add(-time.Hour), now.Add(-time.Hour)),
+		event(corev1.EventTypeNormal, "Pulled", "Pod", "web-1", 3, now, now),
+	}
+
+	r := Events("default", events[3:], now.Add(-15*time.Minute), 5, 20)
+	assert.Equal(t, OK, r.State)
+	assert.Equal(t, "namespace default: no warning events", r.Output)
+	assert.Equal(t, []string{"events=0;5;20;0"}, r.PerfData)
+
+	r = Events("default", events, now.Add(-15*time.Minute), 5, 20)
+	assert.Equal(t, Warning, r.State)
+	assert.Equal(t, "namespace default: 10 warning events, reasons: BackOff (7), FailedMount (2), FailedScheduling (1), "+
+		"objects: pod/web-1 (7), pod/db-0 (2), pod/db-1 (1)", r.Output)
+	assert.Equal(t, []string{"events=10;5;20;0", "BackOff=7;;;0", "FailedMount=2;;;0", "FailedScheduling=1;;;0"}, r.PerfData)
+
+	r = Events("default", events, now.Add(-15*time.Minute), 0, 10)
+	assert.Equal(t, Critical, r.State)
+	assert.Equal(t, "events=10;;10;0", r.PerfData[0])
+}
+
+func testHelmRelease(status string, lastDeployed time.Time) *corev1.Secret {
+	record := fmt.Sprintf(`{"name":"web","namespace":"default","version":3,`+
+		`"info":{"status":"%s","description":"Upgrade complete","last_deployed":"%s"},`+
+		`"chart":{"metadata":{"name":"nginx","version":"1.2.3","appVersion":"1.17"}}}`,
+		status, lastDeployed.Format(time.RFC3339))
+
+	var buf strings.Builder
+	w := gzip.NewWriter(&buf)
+	w.Write([]byte(record))
+	w.Close()
+
+	return &corev1.Secret{
+		Type: "helm.sh/release.v1",
+		Data: map[string][]byte{"release": []byte(base64.StdEncoding.EncodeToString([]byte(buf.String())))},
+	}
+}
+
+func TestHelm(t *testing.T) {
+	deployed := time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	release, err := DecodeHelmRelease(testHelmRelease("deployed", deployed))
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.Equal(t, "web", release.Name)
+	assert.Equal(t, 3, release.Version)
+	assert.Equal(t, "nginx", release.Chart.Metadata.Name)
+
+	r := Helm(release, 10*time.Minute)
+	assert.Equal(t, OK, r.State)
+	assert.Equal(t, "helm release web: revision 3 of chart nginx-1.2.3 deployed at 2019-01-01T00:00:00Z", r.Output)
+	assert.Equal(t, []string{"revision=3;;;0"}, r.PerfData)
+
+	release, _ = DecodeHelmRelease(testHelmRelease("failed", deployed))
+	assert.Equal(t, Critical, Helm(release, 10*time.Minute).State)
+
+	release, _ = DecodeHelmRelease(testHelmRelease("pending-upgrade", deployed))
+	assert.Equal(t, Critical, Helm(release, 10*time.Minute).State)
+
+	release, _ = DecodeHelmRelease(testHelmRelease("pending-upgrade", time.Now()))
+	assert.Equal(t, OK, Helm(release, 10*time.Minute).State)
+
+	release, _ = DecodeHelmRelease(testHelmRelease("uninstalling", deployed))
+	assert.Equal(t, Warning, Helm(release, 10*time.Minute).State)
+
+	_, err = DecodeHelmRelease(&corev1.Secret{Data: map[string][]byte{"release": []byte("not base64!")}})
+	assert.NotNil(t, err)
+}
+
+func TestReplicaDetails(t *testing.T) {
+	ss := &appsv1.StatefulSet{ObjectMeta: metav1.ObjectMeta{Name: "web"}}
+	ds := &appsv1.DaemonSet{ObjectMeta: metav1.ObjectMeta{Name: "fluentd"}}
+
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "web-0"},
+		Status: corev1.PodStatus{
+			Phase:             corev1.PodRunning,
+			ContainerStatuses: []corev1.ContainerStatus{{Name: "web", Ready: true}},
+		},
+	}
+
+	r := StatefulSetReplica(ss, "web-0", pod)
+	assert.Equal(t, OK, r.State)
+	assert.Equal(t, "statefulset web: pod web-0 is Running, 1 of 1 containers ready", r.Output)
+
+	r = StatefulSetReplica(ss, "web-1", nil)
+	assert.Equal(t, Critical, r.State)
+	assert.Equal(t, "statefulset web: pod web-1 does not exist", r.Output)
+
+	pod.Status.ContainerStatuses[0].Ready = false
+	r = DaemonSetPod(ds, "node1", pod)
+	assert.Equal(t, Warning, r.State)
+	assert.Equal(t, "daemonset fluentd on node node1: pod web-0 is Running, 0 of 1 containers ready", r.Output)
+
+	r = DaemonSetPod(ds, "node2", nil)
+	assert.Equal(t, Critical, r.State)
+	assert.Equal(t, "daemonset fluentd: no pod on node node2", r.Output)
+}
+
+func TestRollout(t *testing.T) {
+	replicas := int32(3)
+
+	d := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "web",
+			Generation:  5,
+			Annotations: map[string]string{"deployment.kubernetes.io/revision": "5"},
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicas,
+			Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{Containers: []corev1.Container{{Image: "nginx:1.17"}}}},
+		},
+		Status: appsv1.DeploymentStatus{
+			ObservedGeneration: 5,
+			Replicas:           4,
+			UpdatedReplicas:    1,
+			AvailableReplicas:  3,
+		},
+	}
+
+	rollout := DeploymentRollout(d, "4")
+	assert.True(t, rollout.InProgress)
+
+	r := RolloutProgress(rollout, time.Now(), 10*time.Minute)
+	assert.Equal(t, OK, r.State)
+	assert.Contains(t, r.Output, "deployment web: rollout from revision 4 to 5 in progress since ")
+	assert.Equal(t, []string{"updated=1;;;0;3"}, r.PerfData)
+
+	r = RolloutProgress(rollout, time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC), 10*time.Minute)
+	assert.Equal(t, Warning, r.State)
+	assert.Equal(t, "deployment web: rollout from revision 4 to 5 in progress since 2019-01-01T00:00:00Z, 1 of 3 replicas updated, images nginx:1.17", r.Output)
+
+	d.Status.Conditions = []appsv1.DeploymentCondition{{
+		Type:    appsv1.DeploymentProgressing,
+		Status:  corev1.ConditionFalse,
+		Reason:  "ProgressDeadlineExceeded",
+		Message: `ReplicaSet "web-5d8f" has timed out progressing.`,
+	}}
+	r = RolloutProgress(DeploymentRollout(d, "4"), time.Now(), 10*time.Minute)
+	assert.Equal(t, Critical, r.State)
+	assert.Equal(t, `deployment web: rollout from revision 4 to 5 failed: ReplicaSet "web-5d8f" has timed out progressing., 1 of 3 replicas updated, images nginx:1.17`, r.Output)
+
+	d.Status = appsv1.DeploymentStatus{ObservedGeneration: 5, Replicas: 3, UpdatedReplicas: 3, AvailableReplicas: 3}
+	rollout = DeploymentRollout(d, "5")
+	assert.False(t, rollout.InProgress)
+	r = RolloutProgress(rollout, time.Time{}, 10*time.Minute)
+	assert.Equal(t, OK, r.State)
+	assert.Equal(t, "deployment web: revision 5 rolled out, 3 of 3 replicas updated, images nginx:1.17", r.Output)
+
+	// Unavailable pods of the current template are the business of the check of the Deployment, not a rollout.
+	d.Status.AvailableReplicas = 1
+	assert.False(t, DeploymentRollout(d, "5").InProgress)
+
+	ss := &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "db"},
+		Spec:       appsv1.StatefulSetSpec{Replicas: &replicas},
+		Status:     appsv1.StatefulSetStatus{CurrentRevision: "db-1", UpdateRevision: "db-2", UpdatedReplicas: 1},
+	}
+	assert.True(t, StatefulSetRollout(ss).InProgress)
+
+	ss.Spec.UpdateStrategy.Type = appsv1.OnDeleteStatefulSetStrategyType
+	assert.False(t, StatefulSetRollout(ss).InProgress)
+
+	ds := &appsv1.DaemonSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "fluentd", Generation: 2},
+		Status:     appsv1.DaemonSetStatus{ObservedGeneration: 2, DesiredNumberScheduled: 3, UpdatedNumberScheduled: 2, NumberAvailable: 3},
+	}
+	assert.True(t, DaemonSetRollout(ds).InProgress)
+
+	ds.Status.UpdatedNumberScheduled = 3
+	assert.False(t, DaemonSetRollout(ds).InProgress)
+
+	ds.Status.NumberAvailable = 1
+	assert.False(t, DaemonSetRollout(ds).InProgress)
+}
@@ -0,0 +1,95 @@
+package health
+
+import (
+	"compress/gzip"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// The parts of a Helm 3 release record we use.
+type HelmRelease struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+	Version   int    `json:"version"`
+	Info      struct {
+		Status       string    `json:"status"`
+		Description  string    `json:"description"`
+		LastDeployed time.Time `json:"last_deployed"`
+	} `json:"info"`
+	Chart struct {
+		Metadata struct {
+			Name       string `json:"name"`
+			Version    string `json:"version"`
+			AppVersion string `json:"appVersion"`
+		} `json:"metadata"`
+	} `json:"chart"`
+}
+
+// Decode the release record in a Secret of type helm.sh/release.v1. It is gzipped JSON, base64 encoded once more
+// on top of the encoding of the Secret.
+func DecodeHelmRelease(secret *corev1.Secret) (*HelmRelease, error) {
+	data, err := base64.StdEncoding.DecodeString(string(secret.Data["release"]))
+	if err != nil {
+		return nil, fmt.Errorf("cannot decode release: %s", err.Error())
+	}
+
+	if strings.HasPrefix(string(data), "\x1f\x8b") {
+		r, err := gzip.NewReader(strings.NewReader(string(data)))
+		if err != nil {
+			return nil, fmt.Errorf("cannot decompress release: %s", err.Error())
+		}
+		data, err = ioutil.ReadAll(r)
+		if err != nil {
+			return nil, fmt.Errorf("cannot decompress release: %s", err.Error())
+		}
+	}
+
+	var release HelmRelease
+	if err := json.Unmarshal(data, &release); err != nil {
+		return nil, fmt.Errorf("cannot parse release: %s", err.Error())
+	}
+
+	return &release, nil
+}
+
+// Critical if the latest revision of a Helm release failed or has been pending for longer than grace, warning if it
+// is neither deployed nor pending.
+func Helm(release *HelmRelease, grace time.Duration) Result {
+	info := release.Info
+	meta := release.Chart.Metadata
+
+	r := Result{
+		State: OK,
+		Output: fmt.Sprintf("helm release %s: revision %d of chart %s-%s %s", release.Name, release.Version,
+			meta.Name, meta.Version, info.Status),
+		PerfData: []string{perf("revision", int64(release.Version), 0, -1)},
+	}
+
+	if !info.LastDeployed.IsZero() {
+		r.Output += " at " + info.LastDeployed.UTC().Format(time.RFC3339)
+	}
+
+	switch info.Status {
+	case "deployed":
+	case "failed":
+		r.State = Critical
+	case "pending-install", "pending-upgrade", "pending-rollback":
+		if time.Since(info.LastDeployed) > grace {
+			r.State = Critical
+		}
+	default:
+		r.State = Warning
+	}
+
+	if r.State != OK && info.Description != "" {
+		r.Output += ": " + info.Description
+	}
+
+	return r
+}
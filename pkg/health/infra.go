@@ -0,0 +1,144 @@
+package health
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	coordinationv1 "k8s.io/api/coordination/v1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// Critical if the node is not ready, warning if it reports any other condition (pressure, network unavailable).
+func Node(node *corev1.Node) Result {
+	r := Result{State: Critical, Output: fmt.Sprintf("node %s has not reported its state", node.Name)}
+
+	var problems []string
+	for _, cond := range node.Status.Conditions {
+		if cond.Type == corev1.NodeReady {
+			if cond.Status == corev1.ConditionTrue {
+				r.State = OK
+				r.Output = fmt.Sprintf("node %s is ready", node.Name)
+			} else {
+				r.Output = fmt.Sprintf("node %s is not ready: %s", node.Name, cond.Message)
+			}
+		} else if cond.Status == corev1.ConditionTrue {
+			problems = append(problems, string(cond.Type))
+		}
+	}
+
+	if len(problems) > 0 {
+		r.State = Worst(r.State, Warning)
+		r.Output += ", conditions: " + strings.Join(problems, ", ")
+	}
+
+	return r
+}
+
+// The state of a single node condition: critical if the node is not ready, warning if any other condition
+// (pressure, network unavailable or a problem reported by node-problem-detector) is true.
+func NodeCondition(node *corev1.Node, cond corev1.NodeCondition) Result {
+	r := Result{Output: fmt.Sprintf("node %s: %s is %s", node.Name, cond.Type, cond.Status)}
+	if cond.Reason != "" {
+		r.Output += " (" + cond.Reason + ")"
+	}
+	if cond.Message != "" {
+		r.Output += ": " + cond.Message
+	}
+
+	switch {
+	case cond.Status == corev1.ConditionUnknown:
+		r.State = Unknown
+		if cond.Type == corev1.NodeReady {
+			r.State = Critical
+		}
+	case cond.Type == corev1.NodeReady && cond.Status != corev1.ConditionTrue:
+		r.State = Critical
+	case cond.Type != corev1.NodeReady && cond.Status == corev1.ConditionTrue:
+		r.State = Warning
+	default:
+		r.State = OK
+	}
+
+	return r
+}
+
+// Critical if the kubelet has not renewed the heartbeat lease of its node for longer than grace.
+func NodeLease(node *corev1.Node, lease *coordinationv1.Lease, grace time.Duration) Result {
+	if lease == nil || lease.Spec.RenewTime == nil {
+		return Result{State: Unknown, Output: fmt.Sprintf("node %s has no heartbeat lease", node.Name)}
+	}
+
+	age := time.Since(lease.Spec.RenewTime.Time)
+
+	r := Result{
+		State:  OK,
+		Output: fmt.Sprintf("node %s: heartbeat lease renewed %ds ago", node.Name, int64(age.Seconds())),
+		PerfData: []string{
+			fmt.Sprintf("age=%ds;;%d;0", int64(age.Seconds()), int64(grace.Seconds())),
+		},
+	}
+
+	if age > grace {
+		r.State = Critical
+	}
+
+	return r
+}
+
+// Warning if a node is cordoned.
+func NodeSchedulable(node *corev1.Node) Result {
+	if node.Spec.Unschedulable {
+		return Result{State: Warning, Output: fmt.Sprintf("node %s is cordoned", node.Name)}
+	}
+	return Result{State: OK, Output: fmt.Sprintf("node %s is schedulable", node.Name)}
+}
+
+// A single check of an API server health endpoint like /livez or /readyz.
+type HealthzCheck struct {
+	Name   string
+	Result Result
+}
+
+// Parse the verbose output of an API server health endpoint ("[+]etcd ok", "[-]informer-sync failed: reason
+// withheld") into one result per check, critical if the check failed.
+func Healthz(endpoint, output string) []HealthzCheck {
+	var checks []HealthzCheck
+
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimSpace(line)
+		if len(line) < 4 || line[0] != '[' || line[2] != ']' {
+			continue
+		}
+
+		name := strings.Fields(line[3:])[0]
+		r := Result{State: OK, Output: fmt.Sprintf("%s %s", endpoint, line[3:])}
+		if line[1] != '+' {
+			r.State = Critical
+		}
+
+		checks = append(checks, HealthzCheck{Name: name, Result: r})
+	}
+
+	return checks
+}
+
+// Critical if the component is not healthy.
+func ComponentStatus(cs *corev1.ComponentStatus) Result {
+	for _, cond := range cs.Conditions {
+		if cond.Type != corev1.ComponentHealthy {
+			continue
+		}
+		if cond.Status == corev1.ConditionTrue {
+			return Result{State: OK, Output: fmt.Sprintf("%s is healthy", cs.Name)}
+		}
+		var reasons []string
+		for _, r := range []string{cond.Message, cond.Error} {
+			if r != "" {
+				reasons = append(reasons, r)
+			}
+		}
+		return Result{State: Critical, Output: fmt.Sprintf("%s is unhealthy: %s", cs.Name, strings.Join(reasons, ", "))}
+	}
+	return Result{State: Unknown, Output: fmt.Sprintf("%s has not reported its health", cs.Name)}
+}
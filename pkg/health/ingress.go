@@ -0,0 +1,72 @@
+package health
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	networkingv1beta1 "k8s.io/api/networking/v1beta1"
+)
+
+// Critical if a backend Service does not exist or if the Ingress has not been assigned a load balancer address
+// within grace. services contains the names of the Services in the namespace of the Ingress.
+func Ingress(ing *networkingv1beta1.Ingress, services map[string]bool, grace time.Duration) Result {
+	r := Result{State: OK, Output: fmt.Sprintf("ingress %s", ing.Name)}
+
+	if len(ing.Status.LoadBalancer.Ingress) > 0 {
+		lb := ing.Status.LoadBalancer.Ingress[0]
+		address := lb.IP
+		if address == "" {
+			address = lb.Hostname
+		}
+		r.Output += " has address " + address
+	} else if time.Since(ing.CreationTimestamp.Time) > grace {
+		r.State = Critical
+		r.Output += " has no load balancer address"
+	} else {
+		r.Output += " is waiting for a load balancer address"
+	}
+
+	var missing []string
+	for _, backend := range IngressBackends(ing) {
+		if !services[backend] {
+			missing = append(missing, backend)
+		}
+	}
+
+	if len(missing) > 0 {
+		r.State = Critical
+		r.Output += ", missing backend services: " + strings.Join(missing, ", ")
+	}
+
+	return r
+}
+
+// The names of the Services an Ingress sends traffic to.
+func IngressBackends(ing *networkingv1beta1.Ingress) []string {
+	backends := make(map[string]bool)
+
+	if ing.Spec.Backend != nil && ing.Spec.Backend.ServiceName != "" {
+		backends[ing.Spec.Backend.ServiceName] = true
+	}
+
+	for _, rule := range ing.Spec.Rules {
+		if rule.HTTP == nil {
+			continue
+		}
+		for _, path := range rule.HTTP.Paths {
+			if path.Backend.ServiceName != "" {
+				backends[path.Backend.ServiceName] = true
+			}
+		}
+	}
+
+	var names []string
+	for name := range backends {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
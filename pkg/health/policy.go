@@ -0,0 +1,34 @@
+package health
+
+import (
+	"fmt"
+	"time"
+
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
+)
+
+// Critical if fewer pods are healthy than a PodDisruptionBudget requires, warning if it has not allowed disruptions
+// since blocked (zero if it allows disruptions) for longer than grace, as node drains would hang.
+func PodDisruptionBudget(pdb *policyv1beta1.PodDisruptionBudget, blocked time.Time, grace time.Duration) Result {
+	status := pdb.Status
+
+	r := Result{
+		State: OK,
+		Output: fmt.Sprintf("poddisruptionbudget %s: %d of %d pods healthy, %d required, %d disruptions allowed",
+			pdb.Name, status.CurrentHealthy, status.ExpectedPods, status.DesiredHealthy, status.PodDisruptionsAllowed),
+		PerfData: []string{
+			perf("healthy", int64(status.CurrentHealthy), 0, int64(status.ExpectedPods)),
+			perf("required", int64(status.DesiredHealthy), 0, int64(status.ExpectedPods)),
+			perf("allowed", int64(status.PodDisruptionsAllowed), 0, -1),
+		},
+	}
+
+	if status.CurrentHealthy < status.DesiredHealthy {
+		r.State = Critical
+	} else if status.PodDisruptionsAllowed == 0 && status.ExpectedPods > 0 && !blocked.IsZero() && time.Since(blocked) > grace {
+		r.State = Warning
+		r.Output += " since " + blocked.UTC().Format(time.RFC3339)
+	}
+
+	return r
+}
@@ -0,0 +1,59 @@
+package health
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// Warning or critical if the usage of a resource in a ResourceQuota is at least warning or critical percent of its
+// hard limit. Resources limited to zero are never saturated, they are not meant to be used at all.
+func ResourceQuota(rq *corev1.ResourceQuota, warning, critical int) Result {
+	var names []string
+	for name := range rq.Status.Hard {
+		names = append(names, string(name))
+	}
+	sort.Strings(names)
+
+	r := Result{State: OK}
+	var saturated []string
+
+	for _, name := range names {
+		hard := rq.Status.Hard[corev1.ResourceName(name)]
+		used := rq.Status.Used[corev1.ResourceName(name)]
+
+		h := float64(hard.MilliValue()) / 1000
+		u := float64(used.MilliValue()) / 1000
+
+		r.PerfData = append(r.PerfData, fmt.Sprintf("%s=%g;%g;%g;0;%g", name, u, h*float64(warning)/100, h*float64(critical)/100, h))
+
+		if h == 0 {
+			continue
+		}
+
+		percent := u * 100 / h
+
+		var state State
+		switch {
+		case percent >= float64(critical):
+			state = Critical
+		case percent >= float64(warning):
+			state = Warning
+		default:
+			continue
+		}
+
+		r.State = Worst(r.State, state)
+		saturated = append(saturated, fmt.Sprintf("%s %.1f%% (%s of %s)", name, percent, used.String(), hard.String()))
+	}
+
+	if len(saturated) > 0 {
+		r.Output = fmt.Sprintf("resourcequota %s: %s", rq.Name, strings.Join(saturated, ", "))
+	} else {
+		r.Output = fmt.Sprintf("resourcequota %s: %d resources below %d%%", rq.Name, len(names), warning)
+	}
+
+	return r
+}
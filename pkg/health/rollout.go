@@ -0,0 +1,138 @@
+package health
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// The rollout of a Deployment, StatefulSet or DaemonSet, as far as its status tells.
+type Rollout struct {
+	Kind       string
+	Name       string
+	Current    string
+	Target     string
+	Updated    int32
+	Desired    int32
+	Images     []string
+	InProgress bool
+
+	// Why the rollout failed, empty if it did not.
+	Failed string
+}
+
+// A Deployment is rolling out while not all of its replicas run its latest template. current is the revision of
+// the oldest ReplicaSet that still has pods, the revision of the Deployment if there is none.
+func DeploymentRollout(d *appsv1.Deployment, current string) Rollout {
+	desired := replicas(d.Spec.Replicas)
+	status := d.Status
+
+	r := Rollout{
+		Kind:    "deployment",
+		Name:    d.Name,
+		Current: current,
+		Target:  d.Annotations["deployment.kubernetes.io/revision"],
+		Updated: status.UpdatedReplicas,
+		Desired: desired,
+		Images:  images(d.Spec.Template.Spec),
+		InProgress: !d.Spec.Paused && (d.Generation > status.ObservedGeneration || status.UpdatedReplicas < desired ||
+			status.Replicas > status.UpdatedReplicas),
+	}
+
+	for _, cond := range status.Conditions {
+		if cond.Type == appsv1.DeploymentProgressing && cond.Status == corev1.ConditionFalse && cond.Reason == "ProgressDeadlineExceeded" {
+			r.Failed = cond.Message
+		}
+	}
+
+	return r
+}
+
+// A StatefulSet is rolling out while fewer replicas than expected are of its update revision. With OnDelete, pods
+// are only updated when they are deleted, so it never is.
+func StatefulSetRollout(ss *appsv1.StatefulSet) Rollout {
+	desired := replicas(ss.Spec.Replicas)
+	status := ss.Status
+
+	expected := desired
+	if ru := ss.Spec.UpdateStrategy.RollingUpdate; ru != nil && ru.Partition != nil {
+		expected -= *ru.Partition
+	}
+
+	return Rollout{
+		Kind:    "statefulset",
+		Name:    ss.Name,
+		Current: status.CurrentRevision,
+		Target:  status.UpdateRevision,
+		Updated: status.UpdatedReplicas,
+		Desired: desired,
+		Images:  images(ss.Spec.Template.Spec),
+		InProgress: ss.Generation > status.ObservedGeneration ||
+			ss.Spec.UpdateStrategy.Type != appsv1.OnDeleteStatefulSetStrategyType && status.UpdatedReplicas < expected,
+	}
+}
+
+// A DaemonSet is rolling out while not all of its pods are updated. Its status has no revisions, so the generation
+// it observed and its generation are used instead.
+func DaemonSetRollout(ds *appsv1.DaemonSet) Rollout {
+	status := ds.Status
+
+	return Rollout{
+		Kind:    "daemonset",
+		Name:    ds.Name,
+		Current: strconv.FormatInt(status.ObservedGeneration, 10),
+		Target:  strconv.FormatInt(ds.Generation, 10),
+		Updated: status.UpdatedNumberScheduled,
+		Desired: status.DesiredNumberScheduled,
+		Images:  images(ds.Spec.Template.Spec),
+		InProgress: ds.Generation > status.ObservedGeneration ||
+			ds.Spec.UpdateStrategy.Type != appsv1.OnDeleteDaemonSetStrategyType && status.UpdatedNumberScheduled < status.DesiredNumberScheduled,
+	}
+}
+
+// Critical if a rollout failed, warning if it has been in progress since since for longer than grace.
+func RolloutProgress(r Rollout, since time.Time, grace time.Duration) Result {
+	result := Result{
+		State:    OK,
+		PerfData: []string{perf("updated", int64(r.Updated), 0, int64(r.Desired))},
+	}
+
+	var revision string
+	if r.Current != "" && r.Current != r.Target {
+		revision = fmt.Sprintf("from revision %s to %s", r.Current, r.Target)
+	} else {
+		revision = "to revision " + r.Target
+	}
+
+	switch {
+	case r.Failed != "":
+		result.State = Critical
+		result.Output = fmt.Sprintf("%s %s: rollout %s failed: %s", r.Kind, r.Name, revision, r.Failed)
+	case r.InProgress:
+		result.Output = fmt.Sprintf("%s %s: rollout %s in progress", r.Kind, r.Name, revision)
+		if !since.IsZero() {
+			result.Output += " since " + since.UTC().Format(time.RFC3339)
+			if time.Since(since) > grace {
+				result.State = Warning
+			}
+		}
+	default:
+		result.Output = fmt.Sprintf("%s %s: revision %s rolled out", r.Kind, r.Name, r.Target)
+	}
+
+	result.Output += fmt.Sprintf(", %d of %d replicas updated, images %s", r.Updated, r.Desired, strings.Join(r.Images, ", "))
+
+	return result
+}
+
+func images(spec corev1.PodSpec) []string {
+	var images []string
+	for _, c := range spec.Containers {
+		images = append(images, c.Image)
+	}
+	return images
+}
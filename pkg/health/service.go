@@ -0,0 +1,76 @@
+package health
+
+import (
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// Critical if a Service with a selector has no ready endpoints, warning if some of its endpoints are not ready.
+// LoadBalancer Services are critical if they have not been assigned an ingress within grace.
+func Service(svc *corev1.Service, ep *corev1.Endpoints, grace time.Duration) Result {
+	if svc.Spec.Type == corev1.ServiceTypeExternalName {
+		return Result{State: OK, Output: fmt.Sprintf("service %s points to %s", svc.Name, svc.Spec.ExternalName)}
+	}
+
+	r := Result{State: OK, Output: fmt.Sprintf("service %s", svc.Name)}
+
+	if len(svc.Spec.Selector) > 0 {
+		ready, notReady := endpoints(ep)
+		r.Output += fmt.Sprintf(" has %d ready and %d not ready endpoints", ready, notReady)
+		r.PerfData = []string{
+			perf("ready", ready, 0, -1),
+			perf("notready", notReady, 0, -1),
+		}
+		switch {
+		case ready == 0:
+			r.State = Critical
+			if notReady == 0 {
+				r.Output += ", the selector matches no pods"
+			}
+		case notReady > 0:
+			r.State = Warning
+		}
+	} else {
+		r.Output += " has no selector"
+	}
+
+	if svc.Spec.Type == corev1.ServiceTypeLoadBalancer {
+		if len(svc.Status.LoadBalancer.Ingress) > 0 {
+			ingress := svc.Status.LoadBalancer.Ingress[0]
+			address := ingress.IP
+			if address == "" {
+				address = ingress.Hostname
+			}
+			r.Output += ", load balancer " + address
+		} else if time.Since(svc.CreationTimestamp.Time) > grace {
+			r.State = Critical
+			r.Output += ", no load balancer ingress"
+		} else {
+			r.Output += ", waiting for load balancer ingress"
+		}
+	}
+
+	return r
+}
+
+// The number of ready and not ready addresses. Addresses appear in every subset with the ports they serve.
+func endpoints(ep *corev1.Endpoints) (int64, int64) {
+	if ep == nil {
+		return 0, 0
+	}
+
+	ready := make(map[string]bool)
+	notReady := make(map[string]bool)
+	for _, subset := range ep.Subsets {
+		for _, a := range subset.Addresses {
+			ready[a.IP] = true
+		}
+		for _, a := range subset.NotReadyAddresses {
+			notReady[a.IP] = true
+		}
+	}
+
+	return int64(len(ready)), int64(len(notReady))
+}
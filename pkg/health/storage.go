@@ -0,0 +1,63 @@
+package health
+
+import (
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// Critical if a PersistentVolumeClaim has lost its volume or has been pending for longer than grace.
+func PersistentVolumeClaim(pvc *corev1.PersistentVolumeClaim, grace time.Duration) Result {
+	r := Result{Output: fmt.Sprintf("persistentvolumeclaim %s is %s", pvc.Name, pvc.Status.Phase)}
+
+	switch pvc.Status.Phase {
+	case corev1.ClaimBound:
+		r.State = OK
+		r.Output += " to volume " + pvc.Spec.VolumeName
+		if capacity, ok := pvc.Status.Capacity[corev1.ResourceStorage]; ok {
+			r.Output += " (" + capacity.String() + ")"
+		}
+	case corev1.ClaimPending:
+		if time.Since(pvc.CreationTimestamp.Time) > grace {
+			r.State = Critical
+			r.Output += " since " + pvc.CreationTimestamp.UTC().Format(time.RFC3339)
+		} else {
+			r.State = OK
+		}
+	case corev1.ClaimLost:
+		r.State = Critical
+		r.Output += ", volume " + pvc.Spec.VolumeName + " no longer exists"
+	default:
+		r.State = Unknown
+	}
+
+	return r
+}
+
+// OK if a PersistentVolume is pending, available or bound, warning if it was released and not reclaimed, critical if
+// reclamation failed.
+func PersistentVolume(pv *corev1.PersistentVolume) Result {
+	r := Result{Output: fmt.Sprintf("persistentvolume %s is %s", pv.Name, pv.Status.Phase)}
+
+	if ref := pv.Spec.ClaimRef; ref != nil && pv.Status.Phase != corev1.VolumeAvailable {
+		r.Output += fmt.Sprintf(" (claim %s/%s)", ref.Namespace, ref.Name)
+	}
+
+	switch pv.Status.Phase {
+	case corev1.VolumePending, corev1.VolumeAvailable, corev1.VolumeBound:
+		r.State = OK
+	case corev1.VolumeReleased:
+		r.State = Warning
+	case corev1.VolumeFailed:
+		r.State = Critical
+	default:
+		r.State = Unknown
+	}
+
+	if pv.Status.Message != "" {
+		r.Output += ": " + pv.Status.Message
+	}
+
+	return r
+}
@@ -0,0 +1,89 @@
+package health
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// How to determine the health of an object we only know as unstructured data.
+type Rule struct {
+	// Type of a condition in status.conditions. True is OK, False is critical.
+	Condition string
+
+	// Dotted path to a string field, for example status.phase. Used if Condition is not set.
+	Path string
+
+	// Values of Path that are OK.
+	Healthy []string
+
+	// Values of Path that are a warning. All other values are critical.
+	Warning []string
+}
+
+// Evaluate an object with a rule. Without a rule, the Ready condition is used.
+func Unstructured(typ string, o *unstructured.Unstructured, rule Rule) Result {
+	if rule.Condition == "" && rule.Path == "" {
+		rule.Condition = "Ready"
+	}
+
+	if rule.Condition != "" {
+		return condition(typ, o, rule.Condition)
+	}
+
+	value, found, err := unstructured.NestedString(o.Object, strings.Split(rule.Path, ".")...)
+	if err != nil {
+		return Result{State: Unknown, Output: fmt.Sprintf("%s %s: cannot read %s: %s", typ, o.GetName(), rule.Path, err.Error())}
+	}
+	if !found {
+		return Result{State: Unknown, Output: fmt.Sprintf("%s %s: %s is not set", typ, o.GetName(), rule.Path)}
+	}
+
+	r := Result{State: Critical, Output: fmt.Sprintf("%s %s: %s is %s", typ, o.GetName(), rule.Path, value)}
+	if contains(rule.Healthy, value) {
+		r.State = OK
+	} else if contains(rule.Warning, value) {
+		r.State = Warning
+	}
+
+	return r
+}
+
+func condition(typ string, o *unstructured.Unstructured, conditionType string) Result {
+	conditions, _, _ := unstructured.NestedSlice(o.Object, "status", "conditions")
+
+	for _, c := range conditions {
+		cond, ok := c.(map[string]interface{})
+		if !ok || cond["type"] != conditionType {
+			continue
+		}
+
+		status, _ := cond["status"].(string)
+		r := Result{Output: fmt.Sprintf("%s %s: %s is %s", typ, o.GetName(), conditionType, status)}
+		if message, _ := cond["message"].(string); message != "" {
+			r.Output += ": " + message
+		}
+
+		switch status {
+		case "True":
+			r.State = OK
+		case "False":
+			r.State = Critical
+		default:
+			r.State = Unknown
+		}
+		return r
+	}
+
+	return Result{State: Unknown, Output: fmt.Sprintf("%s %s has no condition %s", typ, o.GetName(), conditionType)}
+}
+
+func contains(list []string, s string) bool {
+	for _, l := range list {
+		if l == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,50 @@
+package health
+
+import (
+	"fmt"
+)
+
+// Warning or critical if used is at least warning or critical percent of capacity. Unknown if the capacity is unknown.
+func Usage(label string, used, capacity uint64, warning, critical int) Result {
+	if capacity == 0 {
+		return Result{State: Unknown, Output: fmt.Sprintf("%s usage unknown", label)}
+	}
+
+	percent := float64(used) * 100 / float64(capacity)
+
+	r := Result{
+		Output: fmt.Sprintf("%s usage %.1f%% (%s of %s)", label, percent, bytes(used), bytes(capacity)),
+		PerfData: []string{
+			fmt.Sprintf("%s=%dB;%d;%d;0;%d", label, used, capacity*uint64(warning)/100, capacity*uint64(critical)/100, capacity),
+		},
+	}
+
+	switch {
+	case percent >= float64(critical):
+		r.State = Critical
+	case percent >= float64(warning):
+		r.State = Warning
+	default:
+		r.State = OK
+	}
+
+	return r
+}
+
+// Format a number of bytes with a binary unit, for example 1.5Gi.
+func bytes(b uint64) string {
+	const unit = 1024
+	if b < unit {
+		return fmt.Sprintf("%d", b)
+	}
+
+	value := float64(b)
+	for _, u := range []string{"Ki", "Mi", "Gi", "Ti", "Pi"} {
+		value /= unit
+		if value < unit {
+			return fmt.Sprintf("%.1f%s", value, u)
+		}
+	}
+
+	return fmt.Sprintf("%.1fEi", value/unit)
+}
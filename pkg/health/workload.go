@@ -0,0 +1,123 @@
+package health
+
+import (
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func Deployment(d *appsv1.Deployment) Result {
+	return Replicas("deployment", d.Name, replicas(d.Spec.Replicas), d.Status.ReadyReplicas, d.Status.AvailableReplicas)
+}
+
+func DaemonSet(ds *appsv1.DaemonSet) Result {
+	return Replicas("daemonset", ds.Name, ds.Status.DesiredNumberScheduled, ds.Status.NumberReady, ds.Status.NumberAvailable)
+}
+
+func ReplicaSet(rs *appsv1.ReplicaSet) Result {
+	return Replicas("replicaset", rs.Name, replicas(rs.Spec.Replicas), rs.Status.ReadyReplicas, rs.Status.AvailableReplicas)
+}
+
+// StatefulSets don't report available replicas, ready is what counts.
+func StatefulSet(ss *appsv1.StatefulSet) Result {
+	return Replicas("statefulset", ss.Name, replicas(ss.Spec.Replicas), ss.Status.ReadyReplicas, ss.Status.ReadyReplicas)
+}
+
+// Warning if some, but not all desired replicas are available; critical if none are.
+func Replicas(typ, name string, desired, ready, available int32) Result {
+	r := Result{
+		Output: fmt.Sprintf("%s %s: %d of %d replicas available, %d ready", typ, name, available, desired, ready),
+		PerfData: []string{
+			perf("desired", int64(desired), 0, -1),
+			perf("ready", int64(ready), 0, int64(desired)),
+			perf("available", int64(available), 0, int64(desired)),
+		},
+	}
+
+	switch {
+	case available >= desired:
+		r.State = OK
+	case available == 0:
+		r.State = Critical
+	default:
+		r.State = Warning
+	}
+
+	return r
+}
+
+// OK if running with all containers ready or completed, warning while pending or not ready, critical if failed.
+func Pod(pod *corev1.Pod) Result {
+	var containers, ready, restarts int64
+	for _, cs := range pod.Status.ContainerStatuses {
+		containers++
+		if cs.Ready {
+			ready++
+		}
+		restarts += int64(cs.RestartCount)
+	}
+
+	r := Result{
+		Output: fmt.Sprintf("pod %s is %s, %d of %d containers ready", pod.Name, pod.Status.Phase, ready, containers),
+		PerfData: []string{
+			perf("containers", containers, 0, -1),
+			perf("ready", ready, 0, containers),
+			perf("restarts", restarts, 0, -1),
+		},
+	}
+
+	if pod.Status.Reason != "" {
+		r.Output += " (" + pod.Status.Reason + ")"
+	}
+
+	switch pod.Status.Phase {
+	case corev1.PodSucceeded:
+		r.State = OK
+	case corev1.PodRunning:
+		if ready == containers {
+			r.State = OK
+		} else {
+			r.State = Warning
+		}
+	case corev1.PodPending:
+		r.State = Warning
+	case corev1.PodFailed:
+		r.State = Critical
+	default:
+		r.State = Unknown
+	}
+
+	return r
+}
+
+// A member of a StatefulSet, named after the pod with its ordinal. Critical if the pod does not exist, otherwise
+// like the pod.
+func StatefulSetReplica(ss *appsv1.StatefulSet, name string, pod *corev1.Pod) Result {
+	if pod == nil {
+		return Result{State: Critical, Output: fmt.Sprintf("statefulset %s: pod %s does not exist", ss.Name, name)}
+	}
+
+	r := Pod(pod)
+	r.Output = fmt.Sprintf("statefulset %s: %s", ss.Name, r.Output)
+	return r
+}
+
+// The pod of a DaemonSet on a node. Critical if there is none, otherwise like the pod.
+func DaemonSetPod(ds *appsv1.DaemonSet, node string, pod *corev1.Pod) Result {
+	if pod == nil {
+		return Result{State: Critical, Output: fmt.Sprintf("daemonset %s: no pod on node %s", ds.Name, node)}
+	}
+
+	r := Pod(pod)
+	r.Output = fmt.Sprintf("daemonset %s on node %s: %s", ds.Name, node, r.Output)
+	return r
+}
+
+// Replicas default to 1 if not set.
+func replicas(r *int32) int32 {
+	if r == nil {
+		return 1
+	}
+	return *r
+}